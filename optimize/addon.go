@@ -0,0 +1,315 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AddOnState represents the state of an add-on.
+type AddOnState string
+
+const (
+	AddOnStateActive     AddOnState = "active"     // Add-on is currently active.
+	AddOnStateSuperseded AddOnState = "superseded" // Add-on has been replaced by another version.
+	AddOnStateDeleted    AddOnState = "deleted"    // Add-on is deleted.
+)
+
+// AddOnType defines how an add-on is billed on a subscription.
+type AddOnType string
+
+const (
+	AddOnTypeOnOff    AddOnType = "on_off"   // Add-on is either on or off, quantity is always 1.
+	AddOnTypeQuantity AddOnType = "quantity" // Add-on is billed per quantity.
+)
+
+// AddOnRange represents the range for retrieving add-ons.
+type AddOnRange string
+
+const (
+	AddOnRangeCreated AddOnRange = "created" // Retrieve add-ons by creation date.
+)
+
+// AddOn defines the structure for an add-on.
+type AddOn struct {
+	// Name of the add-on. Will be used as order line text.
+	Name string `json:"name"`
+
+	// Optional description of the add-on.
+	Description string `json:"description,omitempty"`
+
+	// Add-on amount in the smallest unit for the account currency.
+	Amount int32 `json:"amount"`
+
+	// Optional vat for this add-on. Account default is used if none given.
+	// A pointer, so a zero rated add-on can be told apart from an add-on without vat.
+	Vat *float64 `json:"vat,omitempty"`
+
+	// Per account unique handle for the add-on. Max length 255 with allowable characters [a-zA-Z0-9_.-@].
+	Handle string `json:"handle"`
+
+	// Add-on version.
+	Version int32 `json:"version,omitempty"`
+
+	// Add-on type one of the following: on_off, quantity.
+	Type AddOnType `json:"type"`
+
+	// State of the add-on one of the following: active, superseded, deleted.
+	State AddOnState `json:"state,omitempty"`
+
+	// Currency for the add-on in ISO 4217 three letter alpha code.
+	Currency string `json:"currency,omitempty"`
+
+	// Whether the amount is including VAT. Default true.
+	AmountInclVat bool `json:"amount_incl_vat,omitempty"`
+
+	// Whether the add-on is eligible for all plans.
+	AllPlans bool `json:"all_plans,omitempty"`
+
+	// Plan handles the add-on is eligible for if not eligible for all plans.
+	EligiblePlans []string `json:"eligible_plans,omitempty"`
+
+	// Optional tax policy handle for this add-on. If vat and tax policy is given, vat will be ignored.
+	TaxPolicy string `json:"tax_policy,omitempty"`
+
+	// Date when the add-on was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Date when the add-on was deleted. In ISO-8601 extended offset date-time format.
+	Deleted *time.Time `json:"deleted,omitempty"`
+}
+
+// AddOnSupersede includes additional fields for superseding an add-on.
+type AddOnSupersede struct {
+	AddOn
+	SupersedeMode PlanSupersedeMode `json:"supersede_mode,omitempty"` // Supersede mode for the add-on.
+}
+
+// ListOfAddOnsResponse contains the response for listing add-ons.
+type ListOfAddOnsResponse struct {
+	Size          int        `json:"size"`            // Number of add-ons returned.
+	Count         int        `json:"count"`           // Total count of add-ons.
+	To            string     `json:"to"`              // End of the range.
+	From          string     `json:"from"`            // Start of the range.
+	Content       []*AddOn   `json:"content"`         // List of add-ons.
+	Range         AddOnRange `json:"range"`           // Add-on range.
+	NextPageToken string     `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the add-ons of the page.
+func (r *ListOfAddOnsResponse) Items() []*AddOn {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of add-ons.
+func (r *ListOfAddOnsResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// GetListOfAddOns retrieves a list of add-ons based on the provided query parameters.
+func (b *Billwerk) GetListOfAddOns(ctx context.Context, params ...QueryParamFunc) (*ListOfAddOnsResponse, error) {
+	endpoint := "/list/add_on"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfAddOnsResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetAddOn retrieves a specific add-on by its handle.
+func (b *Billwerk) GetAddOn(ctx context.Context, handle string) (*AddOn, error) {
+	endpoint := fmt.Sprintf("/add_on/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res AddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateAddOn creates a new add-on.
+func (b *Billwerk) CreateAddOn(ctx context.Context, addOn *AddOn) (*AddOn, error) {
+	endpoint := "/add_on"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(addOn)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res AddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SupersedeAddOn supersedes an existing add-on with a new version.
+func (b *Billwerk) SupersedeAddOn(ctx context.Context, handle string, addOn *AddOnSupersede) (*AddOn, error) {
+	endpoint := fmt.Sprintf("/add_on/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(addOn)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res AddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateAddOn updates an existing add-on by its handle.
+func (b *Billwerk) UpdateAddOn(ctx context.Context, handle string, addOn *AddOn) (*AddOn, error) {
+	endpoint := fmt.Sprintf("/add_on/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(addOn)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res AddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteAddOn deletes an add-on by its handle.
+func (b *Billwerk) DeleteAddOn(ctx context.Context, handle string) (*AddOn, error) {
+	endpoint := fmt.Sprintf("/add_on/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res AddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UndeleteAddOn undeletes a previously deleted add-on by its handle.
+func (b *Billwerk) UndeleteAddOn(ctx context.Context, handle string) (*AddOn, error) {
+	endpoint := fmt.Sprintf("/add_on/%s/undelete", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res AddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetAddOnMetadata retrieves the metadata for an add-on by its handle.
+// The result is stored in the metadata parameter and should be a pointer e.g. &map[string]interface{}{}
+// or &struct{}{} with the expected fields / json tags.
+func (b *Billwerk) GetAddOnMetadata(ctx context.Context, handle string, metadata interface{}) error {
+	endpoint := fmt.Sprintf("/add_on/%s/metadata", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return err
+	}
+
+	if err = b.Do(req, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateOrUpdateAddOnMetadata creates or updates the metadata for an add-on by its handle.
+// The response is stored in the metadata parameter and modifies the passed in object.
+func (b *Billwerk) CreateOrUpdateAddOnMetadata(ctx context.Context, handle string, metadata interface{}) error {
+	endpoint := fmt.Sprintf("/add_on/%s/metadata", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(metadata)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return err
+	}
+
+	if err = b.Do(req, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAddOnMetadata deletes metadata associated with a specific add-on by its handle.
+func (b *Billwerk) DeleteAddOnMetadata(ctx context.Context, handle string) error {
+	endpoint := fmt.Sprintf("/add_on/%s/metadata", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return err
+	}
+
+	if err = b.Do(req, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/moonliightz/go-billwerk/pkg/request"
 	"io"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -13,11 +14,22 @@ import (
 // BaseURL is the base URL for all Billwerk Optimize API requests.
 var BaseURL = "https://api.reepay.com/v1"
 
+// CheckoutBaseURL is the default base URL for all Billwerk Optimize Checkout API requests.
+// It can be overridden per client with WithCheckoutBaseURL.
+var CheckoutBaseURL = "https://checkout-api.reepay.com/v1"
+
 // Billwerk represents the API client object.
 type Billwerk struct {
-	apiKey     string
-	apiKeyB64  string
-	httpClient *http.Client
+	apiKey          string
+	apiKeyB64       string
+	httpClient      *http.Client
+	checkoutBaseURL string
+	retryPolicy     *RetryPolicy
+	readLimiter     *tokenBucket
+	writeLimiter    *tokenBucket
+	interceptors    []Interceptor
+	logger          *slog.Logger
+	redactedFields  []string
 }
 
 // Option is a function that sets options for the Billwerk client configuration.
@@ -30,6 +42,14 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithCheckoutBaseURL allows setting a custom base URL for Checkout API requests,
+// e.g. to point the client to a local stand-in in tests.
+func WithCheckoutBaseURL(baseURL string) Option {
+	return func(billwerk *Billwerk) {
+		billwerk.checkoutBaseURL = baseURL
+	}
+}
+
 // New creates a new Billwerk client with an API key and optional configuration options.
 func New(apiKey string, opts ...Option) *Billwerk {
 	b := &Billwerk{
@@ -54,27 +74,51 @@ func (b *Billwerk) newBillwerkRequest(ctx context.Context) request.Builder {
 		WithHeader("Accept", "application/json; charset=utf-8")
 }
 
+// newCheckoutRequest creates a new HTTP request with checkout base URL and authentication.
+func (b *Billwerk) newCheckoutRequest(ctx context.Context) request.Builder {
+	baseURL := b.checkoutBaseURL
+	if baseURL == "" {
+		baseURL = CheckoutBaseURL
+	}
+
+	return b.newBillwerkRequest(ctx).
+		WithBaseURL(baseURL)
+}
+
 // Do executes an HTTP request and json decodes the response into v (if provided).
 //
-// The function checks the status code of the response and returns an error
-// if the status code indicates a failure (4xx or 5xx).
+// The function checks the status code of the response and returns an *ErrorResponse
+// if the status code indicates a failure (4xx or 5xx). Use errors.Is with the sentinel
+// errors, e.g. ErrNotFound, to classify it.
 // If v is not nil, the response body is json decoded into the provided value.
+// Failed requests are retried according to the retry policy of the client.
+// Every attempt waits for the rate limit of the client, see WithRateLimit.
+// Mutating requests are sent with an idempotency key, see WithIdempotencyKey.
+// A key of the context bound to another call fails with ErrIdempotencyKeyConflict.
+// Errors of mutating requests carry the key, see IdempotencyKeyFromError.
+// The request passes the interceptors of the client, see WithInterceptors.
 func (b *Billwerk) Do(req *http.Request, v interface{}) error {
-	res, err := b.httpClient.Do(req)
+	idempotencyKey, err := ensureIdempotencyKey(req)
 	if err != nil {
 		return err
 	}
-	defer func(body io.ReadCloser) {
-		_ = body.Close()
-	}(res.Body)
-
-	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		var errRes ErrorResponse
-		if err = json.NewDecoder(res.Body).Decode(&errRes); err == nil {
-			return errRes
-		}
 
-		return fmt.Errorf("unknown error, status code: %d", res.StatusCode)
+	return withIdempotencyKey(b.do(req, v), idempotencyKey)
+}
+
+// do sends the request through the interceptors and json decodes the response into v (if provided).
+func (b *Billwerk) do(req *http.Request, v interface{}) error {
+	res, err := b.intercept(req, b.roundTrip)
+	if res != nil {
+		defer func(body io.ReadCloser) {
+			_ = body.Close()
+		}(res.Body)
+	}
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		return errNoResponse
 	}
 
 	if v != nil {
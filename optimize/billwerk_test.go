@@ -0,0 +1,24 @@
+package optimize
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestBillwerk creates a client sending its requests to a test server serving handler.
+func newTestBillwerk(t *testing.T, handler http.HandlerFunc, opts ...Option) *Billwerk {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+
+	baseURL := BaseURL
+	BaseURL = server.URL + "/v1"
+
+	t.Cleanup(func() {
+		server.Close()
+		BaseURL = baseURL
+	})
+
+	return New("api-key", opts...)
+}
@@ -0,0 +1,288 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ChargeState represents the state of a charge.
+type ChargeState string
+
+const (
+	ChargeStateCreated    ChargeState = "created"    // Charge is created but not yet processed.
+	ChargeStateAuthorized ChargeState = "authorized" // Charge amount is authorized but not settled.
+	ChargeStateSettled    ChargeState = "settled"    // Charge is settled.
+	ChargeStateFailed     ChargeState = "failed"     // Charge has failed.
+	ChargeStateCancelled  ChargeState = "cancelled"  // Charge is cancelled.
+	ChargeStatePending    ChargeState = "pending"    // Charge is pending an asynchronous result.
+)
+
+// OrderLineCreate defines an order line sent when creating a charge, settling or refunding.
+type OrderLineCreate struct {
+	// Order line text.
+	OrderText string `json:"ordertext"`
+
+	// Per quantity amount in the smallest unit for the account currency.
+	Amount int32 `json:"amount"`
+
+	// Optional vat for the order line as a fraction, e.g. 0.25. Account default is used if none given.
+	Vat *float64 `json:"vat,omitempty"`
+
+	// Optional quantity of the order line. Default is 1.
+	Quantity int32 `json:"quantity,omitempty"`
+
+	// Whether the amount is including vat. Default true.
+	AmountInclVat *bool `json:"amount_incl_vat,omitempty"`
+}
+
+// ChargeSource describes the payment source used for a charge.
+type ChargeSource struct {
+	// Type of the source, e.g. card, card_token, mobilepay, vipps or offline.
+	Type string `json:"type"`
+
+	// Reference to the card or payment method used.
+	Card string `json:"card,omitempty"`
+
+	// Masked card number if the source is a card.
+	MaskedCard string `json:"masked_card,omitempty"`
+
+	// Card fingerprint if the source is a card.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Payment provider used.
+	Provider string `json:"provider,omitempty"`
+
+	// Error code if the source failed.
+	Error string `json:"error,omitempty"`
+
+	// Message from the acquirer if the source failed.
+	AcquirerMessage string `json:"acquirer_message,omitempty"`
+}
+
+// Charge defines the structure for a one-off charge.
+type Charge struct {
+	// Per account unique handle for the charge.
+	Handle string `json:"handle"`
+
+	// State of the charge.
+	State ChargeState `json:"state"`
+
+	// Customer handle.
+	Customer string `json:"customer"`
+
+	// Charge amount including vat.
+	Amount int32 `json:"amount"`
+
+	// Currency for the charge in ISO 4217 three letter alpha code.
+	Currency string `json:"currency"`
+
+	// Id of the last transaction.
+	Transaction string `json:"transaction,omitempty"`
+
+	// Error code if the charge failed.
+	Error string `json:"error,omitempty"`
+
+	// Error state if the charge failed: pending, soft_declined, hard_declined, processing_error.
+	ErrorState string `json:"error_state,omitempty"`
+
+	// Whether the charge is currently being processed.
+	Processing bool `json:"processing,omitempty"`
+
+	// Payment source used for the charge.
+	Source *ChargeSource `json:"source,omitempty"`
+
+	// Order lines of the charge.
+	OrderLines []*OrderLine `json:"order_lines,omitempty"`
+
+	// Refunded amount.
+	RefundedAmount int32 `json:"refunded_amount,omitempty"`
+
+	// Authorized amount.
+	AuthorizedAmount int32 `json:"authorized_amount,omitempty"`
+
+	// Payment method saved for recurring use if the charge was created with recurring.
+	RecurringPaymentMethod string `json:"recurring_payment_method,omitempty"`
+
+	// Date when the charge was authorized.
+	Authorized *time.Time `json:"authorized,omitempty"`
+
+	// Date when the charge was settled.
+	Settled *time.Time `json:"settled,omitempty"`
+
+	// Date when the charge was cancelled.
+	Cancelled *time.Time `json:"cancelled,omitempty"`
+
+	// Date when the charge was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// ChargeCreate defines the request for creating a charge.
+// Either amount or order lines must be given.
+type ChargeCreate struct {
+	// Per account unique handle for the charge. Max length 255 with allowable characters [a-zA-Z0-9_.-@].
+	Handle string `json:"handle"`
+
+	// Handle of an existing customer. Either customer or create_customer must be given.
+	Customer string `json:"customer,omitempty"`
+
+	// Create a customer in conjunction with the charge.
+	CreateCustomer *Customer `json:"create_customer,omitempty"`
+
+	// Payment source, e.g. a card token or an existing payment method id.
+	Source string `json:"source"`
+
+	// Amount to charge in the smallest unit for the account currency.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Optional currency in ISO 4217 three letter alpha code. Account default is used if none given.
+	Currency string `json:"currency,omitempty"`
+
+	// Optional order text used when charging a plain amount.
+	OrderText string `json:"ordertext,omitempty"`
+
+	// Order lines to charge instead of a plain amount.
+	OrderLines []*OrderLineCreate `json:"order_lines,omitempty"`
+
+	// Settle the charge immediately instead of only authorizing it.
+	Settle bool `json:"settle,omitempty"`
+
+	// Save the payment source for recurring use.
+	Recurring bool `json:"recurring,omitempty"`
+
+	// Custom metadata.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ChargeSettle defines the request for settling an authorized charge.
+// Leave all fields empty to settle the full authorized amount.
+type ChargeSettle struct {
+	// Optional amount to settle for a partial settle.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Optional order text for a partial settle.
+	OrderText string `json:"ordertext,omitempty"`
+
+	// Optional order lines replacing the order lines of the charge.
+	OrderLines []*OrderLineCreate `json:"order_lines,omitempty"`
+}
+
+// ChargePrepareRecurring defines the request for saving the payment source of a charge for recurring use.
+type ChargePrepareRecurring struct {
+	// Optional customer handle the payment method is saved for. Default is the charge customer.
+	Customer string `json:"customer,omitempty"`
+}
+
+// GetCharge retrieves a specific charge by its handle.
+func (b *Billwerk) GetCharge(ctx context.Context, handle string) (*Charge, error) {
+	endpoint := fmt.Sprintf("/charge/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Charge
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateCharge creates a new one-off charge.
+// The charge is only authorized unless settle is set.
+func (b *Billwerk) CreateCharge(ctx context.Context, charge *ChargeCreate) (*Charge, error) {
+	endpoint := "/charge"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(charge)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Charge
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SettleCharge settles an authorized charge by its handle.
+// The settle parameter is optional, the full authorized amount is settled if none given.
+func (b *Billwerk) SettleCharge(ctx context.Context, handle string, settle *ChargeSettle) (*Charge, error) {
+	endpoint := fmt.Sprintf("/charge/%s/settle", handle)
+
+	if settle == nil {
+		settle = &ChargeSettle{}
+	}
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(settle)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Charge
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CancelCharge cancels an authorized charge by its handle and releases the authorized amount.
+func (b *Billwerk) CancelCharge(ctx context.Context, handle string) (*Charge, error) {
+	endpoint := fmt.Sprintf("/charge/%s/cancel", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Charge
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// PrepareRecurringCharge saves the payment source of a charge by its handle for recurring use.
+// The prepare parameter is optional.
+func (b *Billwerk) PrepareRecurringCharge(ctx context.Context, handle string, prepare *ChargePrepareRecurring) (*Charge, error) {
+	endpoint := fmt.Sprintf("/charge/%s/prepare_recurring", handle)
+
+	if prepare == nil {
+		prepare = &ChargePrepareRecurring{}
+	}
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(prepare)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Charge
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,158 @@
+package optimize
+
+import (
+	"context"
+)
+
+// CheckoutSession defines a hosted checkout session.
+type CheckoutSession struct {
+	// Session id used to open the checkout with the Checkout JavaScript library.
+	ID string `json:"id"`
+
+	// URL of the hosted checkout page.
+	URL string `json:"url"`
+}
+
+// CheckoutSessionOptions defines the options shared by all checkout session types.
+type CheckoutSessionOptions struct {
+	// Optional URL the customer is redirected to after a successful checkout.
+	AcceptURL string `json:"accept_url,omitempty"`
+
+	// Optional URL the customer is redirected to if the checkout is cancelled.
+	CancelURL string `json:"cancel_url,omitempty"`
+
+	// Optional payment methods to offer, e.g. card, mobilepay or sepa. Account default is used if none given.
+	PaymentMethods []string `json:"payment_methods,omitempty"`
+
+	// Optional locale of the checkout page, e.g. da_DK or en_GB.
+	Locale string `json:"locale,omitempty"`
+
+	// Optional time to live of the session in ISO-8601 duration format, e.g. PT1H.
+	TTL string `json:"ttl,omitempty"`
+}
+
+// ChargeSessionOrder defines the order to charge in a charge session.
+// Either amount or order lines must be given.
+type ChargeSessionOrder struct {
+	// Per account unique handle for the resulting charge.
+	Handle string `json:"handle"`
+
+	// Handle of an existing customer. Either customer_handle or customer must be given.
+	CustomerHandle string `json:"customer_handle,omitempty"`
+
+	// Create a customer in conjunction with the charge.
+	Customer *Customer `json:"customer,omitempty"`
+
+	// Amount to charge in the smallest unit for the account currency.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Optional currency in ISO 4217 three letter alpha code. Account default is used if none given.
+	Currency string `json:"currency,omitempty"`
+
+	// Optional order text used when charging a plain amount.
+	OrderText string `json:"ordertext,omitempty"`
+
+	// Order lines to charge instead of a plain amount.
+	OrderLines []*OrderLineCreate `json:"order_lines,omitempty"`
+
+	// Custom metadata.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ChargeSessionCreate defines the request for creating a charge session.
+type ChargeSessionCreate struct {
+	CheckoutSessionOptions
+
+	// The order to charge.
+	Order *ChargeSessionOrder `json:"order"`
+
+	// Settle the charge immediately instead of only authorizing it.
+	Settle bool `json:"settle,omitempty"`
+
+	// Save the payment method for recurring use.
+	Recurring bool `json:"recurring,omitempty"`
+}
+
+// SubscriptionSessionCreate defines the request for creating a subscription session
+// to add a payment method to a pending subscription.
+type SubscriptionSessionCreate struct {
+	CheckoutSessionOptions
+
+	// Handle of the subscription to add the payment method to.
+	Subscription string `json:"subscription"`
+}
+
+// RecurringSessionCreate defines the request for creating a recurring session
+// to save a payment method for a customer without charging.
+type RecurringSessionCreate struct {
+	CheckoutSessionOptions
+
+	// Handle of an existing customer. Either customer or create_customer must be given.
+	Customer string `json:"customer,omitempty"`
+
+	// Create a customer in conjunction with the session.
+	CreateCustomer *Customer `json:"create_customer,omitempty"`
+}
+
+// CreateChargeSession creates a checkout session for a one-off charge.
+func (b *Billwerk) CreateChargeSession(ctx context.Context, session *ChargeSessionCreate) (*CheckoutSession, error) {
+	endpoint := "/session/charge"
+
+	requestBuilder := b.newCheckoutRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(session)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res CheckoutSession
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateSubscriptionSession creates a checkout session for adding a payment method to a subscription.
+func (b *Billwerk) CreateSubscriptionSession(ctx context.Context, session *SubscriptionSessionCreate) (*CheckoutSession, error) {
+	endpoint := "/session/subscription"
+
+	requestBuilder := b.newCheckoutRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(session)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res CheckoutSession
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateRecurringSession creates a checkout session for saving a payment method for a customer.
+func (b *Billwerk) CreateRecurringSession(ctx context.Context, session *RecurringSessionCreate) (*CheckoutSession, error) {
+	endpoint := "/session/recurring"
+
+	requestBuilder := b.newCheckoutRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(session)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res CheckoutSession
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
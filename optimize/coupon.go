@@ -0,0 +1,267 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CouponState represents the state of a coupon.
+type CouponState string
+
+const (
+	CouponStateActive  CouponState = "active"  // Coupon can be redeemed.
+	CouponStateExpired CouponState = "expired" // Coupon has expired.
+	CouponStateDeleted CouponState = "deleted" // Coupon is deleted.
+)
+
+// CouponRange represents the range for retrieving coupons.
+type CouponRange string
+
+const (
+	CouponRangeCreated CouponRange = "created" // Retrieve coupons by creation date.
+)
+
+// Coupon defines the structure for a coupon.
+type Coupon struct {
+	// Per account unique handle for the coupon. Max length 255 with allowable characters [a-zA-Z0-9_.-@].
+	Handle string `json:"handle"`
+
+	// Name of the coupon.
+	Name string `json:"name"`
+
+	// Code the customer uses to redeem the coupon.
+	Code string `json:"code"`
+
+	// Handle of the discount applied when the coupon is redeemed.
+	Discount string `json:"discount"`
+
+	// State of the coupon one of the following: active, expired, deleted.
+	State CouponState `json:"state,omitempty"`
+
+	// Whether the coupon is eligible for all plans.
+	AllPlans bool `json:"all_plans,omitempty"`
+
+	// Plan handles the coupon is eligible for if not eligible for all plans.
+	EligiblePlans []string `json:"eligible_plans,omitempty"`
+
+	// Optional maximum number of redemptions.
+	MaxRedemptions int32 `json:"max_redemptions,omitempty"`
+
+	// Number of times the coupon has been redeemed.
+	Redemptions int32 `json:"redemptions,omitempty"`
+
+	// Optional date and time until which the coupon can be redeemed.
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+
+	// Date when the coupon expired. In ISO-8601 extended offset date-time format.
+	Expired *time.Time `json:"expired,omitempty"`
+
+	// Date when the coupon was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Date when the coupon was deleted. In ISO-8601 extended offset date-time format.
+	Deleted *time.Time `json:"deleted,omitempty"`
+}
+
+// ListOfCouponsResponse contains the response for listing coupons.
+type ListOfCouponsResponse struct {
+	Size          int         `json:"size"`            // Number of coupons returned.
+	Count         int         `json:"count"`           // Total count of coupons.
+	To            string      `json:"to"`              // End of the range.
+	From          string      `json:"from"`            // Start of the range.
+	Content       []*Coupon   `json:"content"`         // List of coupons.
+	Range         CouponRange `json:"range"`           // Coupon range.
+	NextPageToken string      `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the coupons of the page.
+func (r *ListOfCouponsResponse) Items() []*Coupon {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of coupons.
+func (r *ListOfCouponsResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// CouponRedeem defines the request for redeeming a coupon code on a subscription.
+type CouponRedeem struct {
+	// Coupon code to redeem.
+	Code string `json:"code"`
+}
+
+// GetListOfCoupons retrieves a list of coupons based on the provided query parameters.
+func (b *Billwerk) GetListOfCoupons(ctx context.Context, params ...QueryParamFunc) (*ListOfCouponsResponse, error) {
+	endpoint := "/list/coupon"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfCouponsResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetCoupon retrieves a specific coupon by its handle.
+func (b *Billwerk) GetCoupon(ctx context.Context, handle string) (*Coupon, error) {
+	endpoint := fmt.Sprintf("/coupon/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Coupon
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateCoupon creates a new coupon.
+func (b *Billwerk) CreateCoupon(ctx context.Context, coupon *Coupon) (*Coupon, error) {
+	endpoint := "/coupon"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(coupon)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Coupon
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateCoupon updates an existing coupon by its handle.
+func (b *Billwerk) UpdateCoupon(ctx context.Context, handle string, coupon *Coupon) (*Coupon, error) {
+	endpoint := fmt.Sprintf("/coupon/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(coupon)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Coupon
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteCoupon deletes a coupon by its handle.
+func (b *Billwerk) DeleteCoupon(ctx context.Context, handle string) (*Coupon, error) {
+	endpoint := fmt.Sprintf("/coupon/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Coupon
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ExpireCoupon expires a coupon by its handle so it can no longer be redeemed.
+func (b *Billwerk) ExpireCoupon(ctx context.Context, handle string) (*Coupon, error) {
+	endpoint := fmt.Sprintf("/coupon/%s/expire", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Coupon
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ValidateCouponCode validates a coupon code and returns the matching coupon if it can be redeemed.
+// Use WithQueryParam(PlanHandle, ...) and WithQueryParam(CustomerHandle, ...) to validate
+// the code for a specific plan and customer.
+func (b *Billwerk) ValidateCouponCode(ctx context.Context, code string, params ...QueryParamFunc) (*Coupon, error) {
+	endpoint := "/coupon/code/validate"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithParam(string(Code), code)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Coupon
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RedeemSubscriptionCoupon redeems a coupon code on a subscription by its handle
+// and returns the resulting subscription discount.
+func (b *Billwerk) RedeemSubscriptionCoupon(ctx context.Context, handle string, redeem *CouponRedeem) (*SubscriptionDiscount, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/coupon", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(redeem)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res SubscriptionDiscount
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
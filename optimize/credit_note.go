@@ -0,0 +1,133 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreditNoteRange represents the range for retrieving credit notes.
+type CreditNoteRange string
+
+const (
+	CreditNoteRangeCreated CreditNoteRange = "created" // Retrieve credit notes by creation date.
+)
+
+// CreditNoteLine defines a line on a credit note.
+type CreditNoteLine struct {
+	// Credit note line amount.
+	Amount int32 `json:"amount"`
+
+	// Credit note line text.
+	Text string `json:"text"`
+
+	// Quantity of the credit note line.
+	Quantity int32 `json:"quantity"`
+
+	// Vat for the credit note line as a fraction, e.g. 0.25.
+	Vat float64 `json:"vat"`
+
+	// Whether the amount is including vat.
+	AmountInclVat bool `json:"amount_incl_vat"`
+
+	// Id of the order line the credit note line credits, if any.
+	OrderLineID string `json:"order_line_id,omitempty"`
+}
+
+// CreditNote defines the structure for a credit note issued for an invoice.
+type CreditNote struct {
+	// Credit note id.
+	ID string `json:"id"`
+
+	// Invoice id the credit note is issued for.
+	Invoice string `json:"invoice"`
+
+	// Credit note number.
+	Number int32 `json:"number"`
+
+	// Credit note amount.
+	Amount int32 `json:"amount"`
+
+	// Vat amount.
+	AmountVat int32 `json:"amount_vat"`
+
+	// Credit note amount excluding vat.
+	AmountExVat int32 `json:"amount_ex_vat"`
+
+	// Currency for the credit note in ISO 4217 three letter alpha code.
+	Currency string `json:"currency"`
+
+	// Id of the refund transaction if the credit note was issued by a refund.
+	Transaction string `json:"transaction,omitempty"`
+
+	// Lines of the credit note.
+	CreditNoteLines []*CreditNoteLine `json:"credit_note_lines"`
+
+	// Date when the credit note was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// ListOfCreditNotesResponse contains the response for listing credit notes.
+type ListOfCreditNotesResponse struct {
+	Size          int             `json:"size"`            // Number of credit notes returned.
+	Count         int             `json:"count"`           // Total count of credit notes.
+	To            string          `json:"to"`              // End of the range.
+	From          string          `json:"from"`            // Start of the range.
+	Content       []*CreditNote   `json:"content"`         // List of credit notes.
+	Range         CreditNoteRange `json:"range"`           // Credit note range.
+	NextPageToken string          `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the credit notes of the page.
+func (r *ListOfCreditNotesResponse) Items() []*CreditNote {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of credit notes.
+func (r *ListOfCreditNotesResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// GetListOfCreditNotes retrieves a list of credit notes based on the provided query parameters.
+func (b *Billwerk) GetListOfCreditNotes(ctx context.Context, params ...QueryParamFunc) (*ListOfCreditNotesResponse, error) {
+	endpoint := "/list/credit_note"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfCreditNotesResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetCreditNote retrieves a specific credit note by its id.
+func (b *Billwerk) GetCreditNote(ctx context.Context, id string) (*CreditNote, error) {
+	endpoint := fmt.Sprintf("/credit_note/%s", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res CreditNote
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
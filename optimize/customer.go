@@ -0,0 +1,355 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CustomerRange represents the range for retrieving customers.
+type CustomerRange string
+
+const (
+	CustomerRangeCreated CustomerRange = "created" // Retrieve customers by creation date.
+)
+
+// Customer defines the structure for a customer.
+type Customer struct {
+	// Per account unique handle for the customer. Max length 255 with allowable characters [a-zA-Z0-9_.-@].
+	// Must be provided if generate_handle is not defined.
+	Handle string `json:"handle,omitempty"`
+
+	// Customer email.
+	Email string `json:"email,omitempty"`
+
+	// Customer address.
+	Address string `json:"address,omitempty"`
+
+	// Customer address2.
+	Address2 string `json:"address2,omitempty"`
+
+	// Customer city.
+	City string `json:"city,omitempty"`
+
+	// Customer country in ISO 3166-1 alpha-2.
+	Country string `json:"country,omitempty"`
+
+	// Customer phone number.
+	Phone string `json:"phone,omitempty"`
+
+	// Customer company.
+	Company string `json:"company,omitempty"`
+
+	// Customer vat number.
+	Vat string `json:"vat,omitempty"`
+
+	// Customer first name.
+	FirstName string `json:"first_name,omitempty"`
+
+	// Customer last name.
+	LastName string `json:"last_name,omitempty"`
+
+	// Customer postal code.
+	PostalCode string `json:"postal_code,omitempty"`
+
+	// Optional customer debtor id for use in some accounting systems.
+	DebtorID int64 `json:"debtor_id,omitempty"`
+
+	// Customer language in ISO 639-1 two letter code.
+	Language string `json:"language,omitempty"`
+
+	// Whether the customer is a test customer.
+	Test bool `json:"test,omitempty"`
+
+	// Auto generate handle on the form cust-[sequence_number]. Only used when creating a customer.
+	GenerateHandle bool `json:"generate_handle,omitempty"`
+
+	// Custom metadata. Only used when creating a customer, use the metadata methods afterwards.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// Number of subscriptions.
+	Subscriptions int32 `json:"subscriptions,omitempty"`
+
+	// Number of active subscriptions.
+	ActiveSubscriptions int32 `json:"active_subscriptions,omitempty"`
+
+	// Number of trial subscriptions.
+	Trials int32 `json:"trials,omitempty"`
+
+	// Number of cancelled subscriptions.
+	CancelledSubscriptions int32 `json:"cancelled_subscriptions,omitempty"`
+
+	// Number of non renewing subscriptions.
+	NonRenewingSubscriptions int32 `json:"non_renewing_subscriptions,omitempty"`
+
+	// Number of on hold subscriptions.
+	OnHoldSubscriptions int32 `json:"on_hold_subscriptions,omitempty"`
+
+	// Number of expired subscriptions.
+	ExpiredSubscriptions int32 `json:"expired_subscriptions,omitempty"`
+
+	// Number of invoices in dunning.
+	DunningInvoices int32 `json:"dunning_invoices,omitempty"`
+
+	// Number of failed invoices.
+	FailedInvoices int32 `json:"failed_invoices,omitempty"`
+
+	// Number of pending invoices.
+	PendingInvoices int32 `json:"pending_invoices,omitempty"`
+
+	// Number of cancelled invoices.
+	CancelledInvoices int32 `json:"cancelled_invoices,omitempty"`
+
+	// Number of settled invoices.
+	SettledInvoices int32 `json:"settled_invoices,omitempty"`
+
+	// Total failed amount.
+	FailedAmount int64 `json:"failed_amount,omitempty"`
+
+	// Total pending amount.
+	PendingAmount int64 `json:"pending_amount,omitempty"`
+
+	// Total settled amount.
+	SettledAmount int64 `json:"settled_amount,omitempty"`
+
+	// Total refunded amount.
+	RefundedAmount int64 `json:"refunded_amount,omitempty"`
+
+	// Number of pending additional costs.
+	PendingAdditionalCosts int32 `json:"pending_additional_costs,omitempty"`
+
+	// Total pending additional cost amount.
+	PendingAdditionalCostAmount int64 `json:"pending_additional_cost_amount,omitempty"`
+
+	// Number of transferred additional costs.
+	TransferredAdditionalCosts int32 `json:"transferred_additional_costs,omitempty"`
+
+	// Total transferred additional cost amount.
+	TransferredAdditionalCostAmount int64 `json:"transferred_additional_cost_amount,omitempty"`
+
+	// Number of pending credits.
+	PendingCredits int32 `json:"pending_credits,omitempty"`
+
+	// Total pending credit amount.
+	PendingCreditAmount int64 `json:"pending_credit_amount,omitempty"`
+
+	// Number of transferred credits.
+	TransferredCredits int32 `json:"transferred_credits,omitempty"`
+
+	// Total transferred credit amount.
+	TransferredCreditAmount int64 `json:"transferred_credit_amount,omitempty"`
+
+	// Date when the customer was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Date when the customer was deleted. In ISO-8601 extended offset date-time format.
+	Deleted *time.Time `json:"deleted,omitempty"`
+}
+
+// ListOfCustomersResponse contains the response for listing customers.
+type ListOfCustomersResponse struct {
+	Size          int           `json:"size"`            // Number of customers returned.
+	Count         int           `json:"count"`           // Total count of customers.
+	To            string        `json:"to"`              // End of the range.
+	From          string        `json:"from"`            // Start of the range.
+	Content       []*Customer   `json:"content"`         // List of customers.
+	Range         CustomerRange `json:"range"`           // Customer range.
+	NextPageToken string        `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the customers of the page.
+func (r *ListOfCustomersResponse) Items() []*Customer {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of customers.
+func (r *ListOfCustomersResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// GetListOfCustomers retrieves a list of customers based on the provided query parameters.
+func (b *Billwerk) GetListOfCustomers(ctx context.Context, params ...QueryParamFunc) (*ListOfCustomersResponse, error) {
+	endpoint := "/list/customer"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfCustomersResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetCustomer retrieves a specific customer by its handle.
+func (b *Billwerk) GetCustomer(ctx context.Context, handle string) (*Customer, error) {
+	endpoint := fmt.Sprintf("/customer/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Customer
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateCustomer creates a new customer.
+func (b *Billwerk) CreateCustomer(ctx context.Context, customer *Customer) (*Customer, error) {
+	endpoint := "/customer"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(customer)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Customer
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateCustomer updates an existing customer by its handle.
+func (b *Billwerk) UpdateCustomer(ctx context.Context, handle string, customer *Customer) (*Customer, error) {
+	endpoint := fmt.Sprintf("/customer/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(customer)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Customer
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteCustomer deletes a customer by its handle.
+func (b *Billwerk) DeleteCustomer(ctx context.Context, handle string) (*Customer, error) {
+	endpoint := fmt.Sprintf("/customer/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Customer
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UndeleteCustomer undeletes a previously deleted customer by its handle.
+func (b *Billwerk) UndeleteCustomer(ctx context.Context, handle string) (*Customer, error) {
+	endpoint := fmt.Sprintf("/customer/%s/undelete", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Customer
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetCustomerMetadata retrieves the metadata for a customer by its handle.
+// The result is stored in the metadata parameter and should be a pointer e.g. &map[string]interface{}{}
+// or &struct{}{} with the expected fields / json tags.
+func (b *Billwerk) GetCustomerMetadata(ctx context.Context, handle string, metadata interface{}) error {
+	endpoint := fmt.Sprintf("/customer/%s/metadata", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return err
+	}
+
+	if err = b.Do(req, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateOrUpdateCustomerMetadata creates or updates the metadata for a customer by its handle.
+// The response is stored in the metadata parameter and modifies the passed in object.
+func (b *Billwerk) CreateOrUpdateCustomerMetadata(ctx context.Context, handle string, metadata interface{}) error {
+	endpoint := fmt.Sprintf("/customer/%s/metadata", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(metadata)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return err
+	}
+
+	if err = b.Do(req, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteCustomerMetadata deletes metadata associated with a specific customer by its handle.
+func (b *Billwerk) DeleteCustomerMetadata(ctx context.Context, handle string) error {
+	endpoint := fmt.Sprintf("/customer/%s/metadata", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return err
+	}
+
+	if err = b.Do(req, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
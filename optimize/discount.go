@@ -0,0 +1,338 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DiscountState represents the state of a discount.
+type DiscountState string
+
+const (
+	DiscountStateActive  DiscountState = "active"  // Discount is currently active.
+	DiscountStateDeleted DiscountState = "deleted" // Discount is deleted.
+)
+
+// DiscountApplyTo defines which order lines a discount applies to.
+type DiscountApplyTo string
+
+const (
+	DiscountApplyToAll            DiscountApplyTo = "all"             // Apply to all order lines.
+	DiscountApplyToSetupFee       DiscountApplyTo = "setup_fee"       // Apply to setup fee.
+	DiscountApplyToPlan           DiscountApplyTo = "plan"            // Apply to plan order lines.
+	DiscountApplyToAdditionalCost DiscountApplyTo = "additional_cost" // Apply to additional costs.
+	DiscountApplyToAddOn          DiscountApplyTo = "add_on"          // Apply to add-ons.
+	DiscountApplyToOndemand       DiscountApplyTo = "ondemand"        // Apply to on demand invoices.
+)
+
+// DiscountFixedPeriodUnit represents time units for fixed discount periods.
+type DiscountFixedPeriodUnit string
+
+const (
+	DiscountFixedPeriodUnitDays   DiscountFixedPeriodUnit = "days"   // Fixed period in days.
+	DiscountFixedPeriodUnitMonths DiscountFixedPeriodUnit = "months" // Fixed period in months.
+)
+
+// DiscountRange represents the range for retrieving discounts.
+type DiscountRange string
+
+const (
+	DiscountRangeCreated DiscountRange = "created" // Retrieve discounts by creation date.
+)
+
+// Discount defines the structure for a discount.
+type Discount struct {
+	// Per account unique handle for the discount. Max length 255 with allowable characters [a-zA-Z0-9_.-@].
+	Handle string `json:"handle"`
+
+	// Name of the discount. Will be used as order line text.
+	Name string `json:"name"`
+
+	// Optional description of the discount.
+	Description string `json:"description,omitempty"`
+
+	// Fixed amount discount in the smallest unit for the account currency. Either amount or percentage must be given.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Percentage discount. Either amount or percentage must be given.
+	Percentage int32 `json:"percentage,omitempty"`
+
+	// State of the discount one of the following: active, deleted.
+	State DiscountState `json:"state,omitempty"`
+
+	// Which order lines the discount applies to. Default is all.
+	ApplyTo []DiscountApplyTo `json:"apply_to,omitempty"`
+
+	// Optional fixed number of invoices the discount applies to.
+	FixedCount int32 `json:"fixed_count,omitempty"`
+
+	// Time unit for the optional fixed period (months, days).
+	FixedPeriodUnit DiscountFixedPeriodUnit `json:"fixed_period_unit,omitempty"`
+
+	// Optional fixed period length the discount applies for. E.g. 3 months.
+	FixedPeriod int32 `json:"fixed_period,omitempty"`
+
+	// Date when the discount was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Date when the discount was deleted. In ISO-8601 extended offset date-time format.
+	Deleted *time.Time `json:"deleted,omitempty"`
+}
+
+// ListOfDiscountsResponse contains the response for listing discounts.
+type ListOfDiscountsResponse struct {
+	Size          int           `json:"size"`            // Number of discounts returned.
+	Count         int           `json:"count"`           // Total count of discounts.
+	To            string        `json:"to"`              // End of the range.
+	From          string        `json:"from"`            // Start of the range.
+	Content       []*Discount   `json:"content"`         // List of discounts.
+	Range         DiscountRange `json:"range"`           // Discount range.
+	NextPageToken string        `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the discounts of the page.
+func (r *ListOfDiscountsResponse) Items() []*Discount {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of discounts.
+func (r *ListOfDiscountsResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// SubscriptionDiscount defines a discount applied to a subscription.
+type SubscriptionDiscount struct {
+	// Per subscription unique handle for the subscription discount.
+	Handle string `json:"handle"`
+
+	// Handle of the applied discount.
+	Discount string `json:"discount"`
+
+	// Handle of the coupon if the discount was applied by redeeming a coupon code.
+	Coupon string `json:"coupon,omitempty"`
+
+	// Name of the discount.
+	Name string `json:"name"`
+
+	// Fixed amount discount.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Percentage discount.
+	Percentage int32 `json:"percentage,omitempty"`
+
+	// Which order lines the discount applies to.
+	ApplyTo []DiscountApplyTo `json:"apply_to,omitempty"`
+
+	// Number of invoices the discount has been applied to.
+	FixedUsage int32 `json:"fixed_usage,omitempty"`
+
+	// Date when the subscription discount was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Date when the subscription discount was deleted. In ISO-8601 extended offset date-time format.
+	Deleted *time.Time `json:"deleted,omitempty"`
+}
+
+// SubscriptionDiscountCreate defines the request for applying a discount to a subscription.
+type SubscriptionDiscountCreate struct {
+	// Handle of the discount to apply.
+	Discount string `json:"discount"`
+
+	// Optional per subscription unique handle for the subscription discount.
+	Handle string `json:"handle,omitempty"`
+
+	// Optional fixed amount overriding the discount amount.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Optional percentage overriding the discount percentage.
+	Percentage int32 `json:"percentage,omitempty"`
+}
+
+// GetListOfDiscounts retrieves a list of discounts based on the provided query parameters.
+func (b *Billwerk) GetListOfDiscounts(ctx context.Context, params ...QueryParamFunc) (*ListOfDiscountsResponse, error) {
+	endpoint := "/list/discount"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfDiscountsResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetDiscount retrieves a specific discount by its handle.
+func (b *Billwerk) GetDiscount(ctx context.Context, handle string) (*Discount, error) {
+	endpoint := fmt.Sprintf("/discount/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Discount
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateDiscount creates a new discount.
+func (b *Billwerk) CreateDiscount(ctx context.Context, discount *Discount) (*Discount, error) {
+	endpoint := "/discount"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(discount)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Discount
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateDiscount updates an existing discount by its handle.
+func (b *Billwerk) UpdateDiscount(ctx context.Context, handle string, discount *Discount) (*Discount, error) {
+	endpoint := fmt.Sprintf("/discount/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(discount)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Discount
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteDiscount deletes a discount by its handle.
+func (b *Billwerk) DeleteDiscount(ctx context.Context, handle string) (*Discount, error) {
+	endpoint := fmt.Sprintf("/discount/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Discount
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UndeleteDiscount undeletes a previously deleted discount by its handle.
+func (b *Billwerk) UndeleteDiscount(ctx context.Context, handle string) (*Discount, error) {
+	endpoint := fmt.Sprintf("/discount/%s/undelete", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Discount
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// AddSubscriptionDiscount applies a discount to a subscription by its handle.
+func (b *Billwerk) AddSubscriptionDiscount(ctx context.Context, handle string, discount *SubscriptionDiscountCreate) (*SubscriptionDiscount, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/discount", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(discount)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res SubscriptionDiscount
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetListOfSubscriptionDiscounts retrieves all discounts applied to a subscription by its handle.
+func (b *Billwerk) GetListOfSubscriptionDiscounts(ctx context.Context, handle string) ([]*SubscriptionDiscount, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/discount", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*SubscriptionDiscount
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteSubscriptionDiscount removes a discount from a subscription
+// by the subscription handle and the subscription discount handle.
+func (b *Billwerk) DeleteSubscriptionDiscount(ctx context.Context, handle string, discountHandle string) (*SubscriptionDiscount, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/discount/%s", handle, discountHandle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res SubscriptionDiscount
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,142 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DunningPlanState represents the state of a dunning plan.
+type DunningPlanState string
+
+const (
+	DunningPlanStateActive  DunningPlanState = "active"  // Dunning plan is currently active.
+	DunningPlanStateDeleted DunningPlanState = "deleted" // Dunning plan is deleted.
+)
+
+// DunningPlan defines the structure for a dunning plan.
+type DunningPlan struct {
+	// Per account unique handle for the dunning plan. Max length 255 with allowable characters [a-zA-Z0-9_.-@].
+	Handle string `json:"handle"`
+
+	// Name of the dunning plan.
+	Name string `json:"name"`
+
+	// Schedule of the dunning plan as number of days between the dunning notifications.
+	// E.g. [3, 5, 7] sends the first notification after 3 days, the second after another 5 days and so on.
+	Schedule []int32 `json:"schedule"`
+
+	// Number of days after the last notification before the invoice fails.
+	FinalDunningDays int32 `json:"final_dunning_days,omitempty"`
+
+	// Whether the dunning plan is the default dunning plan for the account.
+	DefaultPlan bool `json:"default_plan,omitempty"`
+
+	// State of the dunning plan one of the following: active, deleted.
+	State DunningPlanState `json:"state,omitempty"`
+
+	// Date when the dunning plan was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// GetListOfDunningPlans retrieves all dunning plans.
+func (b *Billwerk) GetListOfDunningPlans(ctx context.Context) ([]*DunningPlan, error) {
+	endpoint := "/dunning_plan"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*DunningPlan
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetDunningPlan retrieves a specific dunning plan by its handle.
+func (b *Billwerk) GetDunningPlan(ctx context.Context, handle string) (*DunningPlan, error) {
+	endpoint := fmt.Sprintf("/dunning_plan/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res DunningPlan
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateDunningPlan creates a new dunning plan.
+func (b *Billwerk) CreateDunningPlan(ctx context.Context, dunningPlan *DunningPlan) (*DunningPlan, error) {
+	endpoint := "/dunning_plan"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(dunningPlan)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res DunningPlan
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateDunningPlan updates an existing dunning plan by its handle.
+func (b *Billwerk) UpdateDunningPlan(ctx context.Context, handle string, dunningPlan *DunningPlan) (*DunningPlan, error) {
+	endpoint := fmt.Sprintf("/dunning_plan/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(dunningPlan)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res DunningPlan
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteDunningPlan deletes a dunning plan by its handle.
+func (b *Billwerk) DeleteDunningPlan(ctx context.Context, handle string) (*DunningPlan, error) {
+	endpoint := fmt.Sprintf("/dunning_plan/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res DunningPlan
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,146 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+)
+
+// EntitlementRange represents the range for retrieving entitlements.
+type EntitlementRange string
+
+const (
+	EntitlementRangeCreated EntitlementRange = "created" // Retrieve entitlements by creation date.
+)
+
+// ListOfEntitlementsResponse contains the response for listing entitlements.
+type ListOfEntitlementsResponse struct {
+	Size          int                `json:"size"`            // Number of entitlements returned.
+	Count         int                `json:"count"`           // Total count of entitlements.
+	To            string             `json:"to"`              // End of the range.
+	From          string             `json:"from"`            // Start of the range.
+	Content       []*PlanEntitlement `json:"content"`         // List of entitlements.
+	Range         EntitlementRange   `json:"range"`           // Entitlement range.
+	NextPageToken string             `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the entitlements of the page.
+func (r *ListOfEntitlementsResponse) Items() []*PlanEntitlement {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of entitlements.
+func (r *ListOfEntitlementsResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// PlanEntitlementsAdd defines the request for adding entitlements to a plan version.
+type PlanEntitlementsAdd struct {
+	// Handles of the entitlements to add.
+	Entitlements []string `json:"entitlements"`
+}
+
+// GetListOfEntitlements retrieves a list of entitlements based on the provided query parameters.
+func (b *Billwerk) GetListOfEntitlements(ctx context.Context, params ...QueryParamFunc) (*ListOfEntitlementsResponse, error) {
+	endpoint := "/list/entitlement"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfEntitlementsResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetEntitlement retrieves a specific entitlement by its handle.
+func (b *Billwerk) GetEntitlement(ctx context.Context, handle string) (*PlanEntitlement, error) {
+	endpoint := fmt.Sprintf("/entitlement/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res PlanEntitlement
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateEntitlement creates a new entitlement.
+func (b *Billwerk) CreateEntitlement(ctx context.Context, entitlement *PlanEntitlement) (*PlanEntitlement, error) {
+	endpoint := "/entitlement"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(entitlement)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res PlanEntitlement
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateEntitlement updates an existing entitlement by its handle.
+func (b *Billwerk) UpdateEntitlement(ctx context.Context, handle string, entitlement *PlanEntitlement) (*PlanEntitlement, error) {
+	endpoint := fmt.Sprintf("/entitlement/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(entitlement)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res PlanEntitlement
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteEntitlement deletes an entitlement by its handle.
+func (b *Billwerk) DeleteEntitlement(ctx context.Context, handle string) (*PlanEntitlement, error) {
+	endpoint := fmt.Sprintf("/entitlement/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res PlanEntitlement
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,299 @@
+package entitlement
+
+import (
+	"context"
+	"fmt"
+	"github.com/moonliightz/go-billwerk/optimize"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is the default time entitlements are cached.
+const DefaultTTL = 5 * time.Minute
+
+// DefaultLookupTimeout is the default time a lookup of the entitlements of a customer may take.
+const DefaultLookupTimeout = 30 * time.Second
+
+// Checker resolves the entitlements of a customer from the plans of its active subscriptions
+// and caches them. It is safe for concurrent use.
+type Checker struct {
+	billwerk      *optimize.Billwerk
+	ttl           time.Duration
+	lookupTimeout time.Duration
+	now           func() time.Time
+
+	mu         sync.Mutex
+	customers  map[string]cacheEntry
+	plans      map[planVersion]cacheEntry
+	lastSweep  time.Time
+	generation uint64
+
+	flightMu sync.Mutex
+	flights  map[string]*flight
+}
+
+// flight is a lookup of the entitlements of a customer in progress.
+// Concurrent lookups for the same customer wait for it instead of calling the API again.
+// The lookup is not bound to the context of the caller starting it, so callers cancelling
+// their context do not fail the lookup for the other callers.
+type flight struct {
+	done         chan struct{}
+	entitlements map[string]struct{}
+	err          error
+}
+
+// cacheEntry is a cached set of entitlement handles.
+type cacheEntry struct {
+	entitlements map[string]struct{}
+	expires      time.Time
+}
+
+// planVersion identifies a version of a plan.
+type planVersion struct {
+	handle  string
+	version int32
+}
+
+// Option is a function that sets options for the Checker configuration.
+type Option func(checker *Checker)
+
+// WithTTL allows setting the time entitlements are cached. A TTL of zero disables caching.
+func WithTTL(ttl time.Duration) Option {
+	return func(checker *Checker) {
+		checker.ttl = ttl
+	}
+}
+
+// WithLookupTimeout allows setting the time a lookup of the entitlements of a customer may take.
+// Default is DefaultLookupTimeout.
+func WithLookupTimeout(timeout time.Duration) Option {
+	return func(checker *Checker) {
+		checker.lookupTimeout = timeout
+	}
+}
+
+// WithClock allows setting the function used to get the current time.
+func WithClock(now func() time.Time) Option {
+	return func(checker *Checker) {
+		checker.now = now
+	}
+}
+
+// NewChecker creates a new Checker using the Billwerk client and optional configuration options.
+func NewChecker(billwerk *optimize.Billwerk, opts ...Option) *Checker {
+	c := &Checker{
+		billwerk:      billwerk,
+		ttl:           DefaultTTL,
+		lookupTimeout: DefaultLookupTimeout,
+		now:           time.Now,
+		customers:     make(map[string]cacheEntry),
+		plans:         make(map[planVersion]cacheEntry),
+		flights:       make(map[string]*flight),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Has reports whether the customer has the entitlement through one of its active subscriptions.
+func (c *Checker) Has(ctx context.Context, customerHandle string, entitlementHandle string) (bool, error) {
+	entitlements, err := c.entitlements(ctx, customerHandle)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := entitlements[entitlementHandle]
+	return ok, nil
+}
+
+// Entitlements returns the handles of all entitlements the customer has through its active subscriptions.
+func (c *Checker) Entitlements(ctx context.Context, customerHandle string) ([]string, error) {
+	entitlements, err := c.entitlements(ctx, customerHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	handles := make([]string, 0, len(entitlements))
+	for handle := range entitlements {
+		handles = append(handles, handle)
+	}
+
+	return handles, nil
+}
+
+// Invalidate removes the cached entitlements of a customer,
+// e.g. after receiving a webhook for a subscription change.
+// Lookups already in progress do not cache their result and later calls start a new lookup.
+func (c *Checker) Invalidate(customerHandle string) {
+	c.mu.Lock()
+	delete(c.customers, customerHandle)
+	c.generation++
+	c.mu.Unlock()
+
+	c.flightMu.Lock()
+	delete(c.flights, customerHandle)
+	c.flightMu.Unlock()
+}
+
+// entitlements returns the cached entitlements of a customer or resolves them if the cache is expired.
+// Concurrent calls for the same customer share a single lookup.
+func (c *Checker) entitlements(ctx context.Context, customerHandle string) (map[string]struct{}, error) {
+	if entitlements, ok := cached(c, c.customers, customerHandle); ok {
+		return entitlements, nil
+	}
+
+	c.flightMu.Lock()
+	f, ok := c.flights[customerHandle]
+	if !ok {
+		f = &flight{done: make(chan struct{})}
+		c.flights[customerHandle] = f
+		go c.lookup(ctx, customerHandle, f)
+	}
+	c.flightMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-f.done:
+		return f.entitlements, f.err
+	}
+}
+
+// lookup resolves the entitlements of a customer for a flight and caches them,
+// unless the customer was invalidated in the meantime.
+func (c *Checker) lookup(ctx context.Context, customerHandle string, f *flight) {
+	c.mu.Lock()
+	generation := c.generation
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.lookupTimeout)
+	defer cancel()
+
+	f.entitlements, f.err = c.resolve(ctx, customerHandle)
+	if f.err == nil {
+		c.mu.Lock()
+		if c.generation == generation {
+			insert(c, c.customers, customerHandle, f.entitlements)
+		}
+		c.mu.Unlock()
+	}
+
+	c.flightMu.Lock()
+	if c.flights[customerHandle] == f {
+		delete(c.flights, customerHandle)
+	}
+	c.flightMu.Unlock()
+	close(f.done)
+}
+
+// resolve retrieves the entitlements of a customer from the plans of its active subscriptions.
+func (c *Checker) resolve(ctx context.Context, customerHandle string) (map[string]struct{}, error) {
+	subscriptions, err := c.activeSubscriptions(ctx, customerHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	entitlements := make(map[string]struct{})
+	for _, subscription := range subscriptions {
+		planEntitlements, err := c.planEntitlements(ctx, planVersion{subscription.Plan, subscription.PlanVersion})
+		if err != nil {
+			return nil, err
+		}
+
+		for handle := range planEntitlements {
+			entitlements[handle] = struct{}{}
+		}
+	}
+
+	return entitlements, nil
+}
+
+// activeSubscriptions retrieves all active subscriptions of a customer.
+func (c *Checker) activeSubscriptions(ctx context.Context, customerHandle string) ([]*optimize.Subscription, error) {
+	var subscriptions []*optimize.Subscription
+	for subscription, err := range optimize.Paginate(ctx, c.billwerk.GetListOfSubscriptions, 0,
+		optimize.WithQueryParam(optimize.CustomerHandle, customerHandle),
+		optimize.WithQueryParam(optimize.State, optimize.SubscriptionStateActive),
+	) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions of customer %s: %w", customerHandle, err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+// planEntitlements returns the cached entitlements of a plan version or retrieves them if the cache is expired.
+func (c *Checker) planEntitlements(ctx context.Context, plan planVersion) (map[string]struct{}, error) {
+	if entitlements, ok := cached(c, c.plans, plan); ok {
+		return entitlements, nil
+	}
+
+	planEntitlements, err := c.billwerk.GetPlanEntitlements(ctx, plan.handle, plan.version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entitlements of plan %s version %d: %w", plan.handle, plan.version, err)
+	}
+
+	entitlements := make(map[string]struct{}, len(planEntitlements))
+	for _, planEntitlement := range planEntitlements {
+		entitlements[planEntitlement.Handle] = struct{}{}
+	}
+
+	store(c, c.plans, plan, entitlements)
+
+	return entitlements, nil
+}
+
+// cached returns the entitlements cached for key if they are not expired.
+func cached[K comparable](c *Checker, cache map[K]cacheEntry, key K) (map[string]struct{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := cache[key]
+	if !ok || !c.now().Before(entry.expires) {
+		return nil, false
+	}
+
+	return entry.entitlements, true
+}
+
+// store caches the entitlements for key until the TTL expires.
+func store[K comparable](c *Checker, cache map[K]cacheEntry, key K, entitlements map[string]struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	insert(c, cache, key, entitlements)
+}
+
+// insert caches the entitlements for key until the TTL expires. The caller must hold c.mu.
+// Expired entries of all caches are evicted at most once per TTL.
+func insert[K comparable](c *Checker, cache map[K]cacheEntry, key K, entitlements map[string]struct{}) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	now := c.now()
+	if now.Sub(c.lastSweep) >= c.ttl {
+		evict(c.customers, now)
+		evict(c.plans, now)
+		c.lastSweep = now
+	}
+
+	cache[key] = cacheEntry{
+		entitlements: entitlements,
+		expires:      now.Add(c.ttl),
+	}
+}
+
+// evict removes the expired entries of a cache.
+func evict[K comparable](cache map[K]cacheEntry, now time.Time) {
+	for key, entry := range cache {
+		if !now.Before(entry.expires) {
+			delete(cache, key)
+		}
+	}
+}
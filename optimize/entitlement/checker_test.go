@@ -0,0 +1,263 @@
+package entitlement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/moonliightz/go-billwerk/optimize"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testAPI serves subscriptions and plan entitlements. Every customer has a subscription of one plan.
+type testAPI struct {
+	mu           sync.Mutex
+	plans        map[string]string
+	entitlements map[string][]string
+
+	// Number of subscription lookups.
+	lookups atomic.Int32
+
+	// If set, subscription lookups signal started and wait for release.
+	started chan struct{}
+	release chan struct{}
+}
+
+func (a *testAPI) setPlan(customer, plan string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.plans[customer] = plan
+}
+
+func (a *testAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/v1/list/subscription":
+		a.lookups.Add(1)
+
+		customer := r.URL.Query().Get("customer")
+		a.mu.Lock()
+		plan := a.plans[customer]
+		a.mu.Unlock()
+
+		if a.started != nil {
+			a.started <- struct{}{}
+			<-a.release
+		}
+
+		_ = json.NewEncoder(w).Encode(optimize.ListOfSubscriptionsResponse{
+			Content: []*optimize.Subscription{{Handle: "sub-" + customer, Plan: plan, PlanVersion: 1}},
+		})
+	case strings.HasPrefix(r.URL.Path, "/v1/plan/"):
+		plan := strings.Split(r.URL.Path, "/")[3]
+
+		a.mu.Lock()
+		var res []*optimize.PlanEntitlement
+		for _, handle := range a.entitlements[plan] {
+			res = append(res, &optimize.PlanEntitlement{Handle: handle})
+		}
+		a.mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(res)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestChecker(t *testing.T, api *testAPI, opts ...Option) *Checker {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+
+	baseURL := optimize.BaseURL
+	optimize.BaseURL = server.URL + "/v1"
+
+	t.Cleanup(func() {
+		server.Close()
+		optimize.BaseURL = baseURL
+	})
+
+	return NewChecker(optimize.New("api-key"), opts...)
+}
+
+func newTestAPI() *testAPI {
+	return &testAPI{
+		plans: map[string]string{"c-1": "gold", "c-2": "free"},
+		entitlements: map[string][]string{
+			"gold": {"api", "export"},
+			"free": nil,
+		},
+	}
+}
+
+func TestCheckerHas(t *testing.T) {
+	api := newTestAPI()
+	checker := newTestChecker(t, api)
+
+	for handle, want := range map[string]bool{"api": true, "export": true, "sso": false} {
+		got, err := checker.Has(context.Background(), "c-1", handle)
+		if err != nil {
+			t.Fatalf("Has() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Has(%q) = %v, want %v", handle, got, want)
+		}
+	}
+
+	handles, err := checker.Entitlements(context.Background(), "c-1")
+	if err != nil {
+		t.Fatalf("Entitlements() error = %v", err)
+	}
+	slices.Sort(handles)
+	if want := []string{"api", "export"}; !slices.Equal(handles, want) {
+		t.Errorf("Entitlements() = %v, want %v", handles, want)
+	}
+
+	if lookups := api.lookups.Load(); lookups != 1 {
+		t.Errorf("lookups = %d, want 1", lookups)
+	}
+}
+
+func TestCheckerCacheExpiry(t *testing.T) {
+	api := newTestAPI()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	checker := newTestChecker(t, api, WithTTL(time.Minute), WithClock(func() time.Time { return now }))
+
+	_, _ = checker.Has(context.Background(), "c-1", "api")
+	_, _ = checker.Has(context.Background(), "c-2", "api")
+
+	now = now.Add(2 * time.Minute)
+	if _, err := checker.Has(context.Background(), "c-1", "api"); err != nil {
+		t.Fatalf("Has() error = %v", err)
+	}
+	if lookups := api.lookups.Load(); lookups != 3 {
+		t.Errorf("lookups = %d, want 3", lookups)
+	}
+
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+	if _, ok := checker.customers["c-2"]; ok {
+		t.Error("expired entry of c-2 not evicted")
+	}
+	if len(checker.customers) != 1 || len(checker.plans) != 1 {
+		t.Errorf("cached %d customers and %d plans, want 1 each", len(checker.customers), len(checker.plans))
+	}
+}
+
+func TestCheckerInvalidate(t *testing.T) {
+	api := newTestAPI()
+	checker := newTestChecker(t, api)
+
+	_, _ = checker.Has(context.Background(), "c-1", "api")
+	checker.Invalidate("c-1")
+	_, _ = checker.Has(context.Background(), "c-1", "api")
+
+	if lookups := api.lookups.Load(); lookups != 2 {
+		t.Errorf("lookups = %d, want 2", lookups)
+	}
+}
+
+func TestCheckerSharedLookup(t *testing.T) {
+	api := newTestAPI()
+	api.started = make(chan struct{})
+	api.release = make(chan struct{})
+	checker := newTestChecker(t, api)
+
+	// The first caller cancels its context while the others wait for the shared lookup.
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 4)
+	go func() {
+		_, err := checker.Has(ctx, "c-1", "api")
+		errs <- err
+	}()
+	<-api.started
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := checker.Has(context.Background(), "c-1", "api")
+			if err == nil && !ok {
+				err = errors.New("entitlement missing")
+			}
+			errs <- err
+		}()
+	}
+
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Has() of cancelled caller error = %v, want %v", err, context.Canceled)
+	}
+
+	close(api.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Has() error = %v", err)
+		}
+	}
+
+	if lookups := api.lookups.Load(); lookups != 1 {
+		t.Errorf("lookups = %d, want 1", lookups)
+	}
+}
+
+func TestCheckerInvalidateDuringLookup(t *testing.T) {
+	api := newTestAPI()
+	api.started = make(chan struct{})
+	api.release = make(chan struct{})
+	checker := newTestChecker(t, api)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = checker.Has(context.Background(), "c-1", "api")
+	}()
+	<-api.started
+
+	api.setPlan("c-1", "free")
+	checker.Invalidate("c-1")
+	close(api.release)
+	<-done
+
+	api.started = nil
+	ok, err := checker.Has(context.Background(), "c-1", "api")
+	if err != nil {
+		t.Fatalf("Has() error = %v", err)
+	}
+	if ok {
+		t.Error("Has() = true, want the result of the lookup started before Invalidate not to be cached")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	api := newTestAPI()
+	checker := newTestChecker(t, api)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := checker.Middleware("api", func(r *http.Request) (string, error) {
+		return r.Header.Get("Customer"), nil
+	})(next)
+
+	for customer, want := range map[string]int{"c-1": http.StatusNoContent, "c-2": http.StatusForbidden, "": http.StatusUnauthorized} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Customer", customer)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("status for customer %q = %d, want %d", customer, rec.Code, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package entitlement
+
+import (
+	"net/http"
+)
+
+// CustomerResolver returns the handle of the customer making the request.
+// An empty handle means the request is not associated with a customer.
+type CustomerResolver func(r *http.Request) (string, error)
+
+// Middleware returns an HTTP middleware that only passes requests of customers having the entitlement.
+//
+// It responds with 401 if resolve returns an empty customer handle, 403 if the customer lacks
+// the entitlement and 500 if the customer or its entitlements could not be resolved.
+func (c *Checker) Middleware(entitlementHandle string, resolve CustomerResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			customerHandle, err := resolve(r)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			if customerHandle == "" {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			ok, err := c.Has(r.Context(), customerHandle, entitlementHandle)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			if !ok {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,5 +1,44 @@
 package optimize
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxErrorBodyBytes is the maximum number of bytes of an error response body that is kept.
+const maxErrorBodyBytes = 64 << 10
+
+// Sentinel errors to classify an ErrorResponse with errors.Is.
+//
+// Example:
+//
+//	if errors.Is(err, ErrNotFound) {
+//		// handle missing resource
+//	}
+var (
+	ErrNotFound            = errors.New("not found")            // The resource does not exist (404).
+	ErrInvalidRequest      = errors.New("invalid request")      // The request was rejected as invalid (4xx).
+	ErrAuthentication      = errors.New("authentication error") // The API key is missing, invalid or lacks permission (401, 403).
+	ErrRateLimited         = errors.New("rate limited")         // Too many requests have been sent (429).
+	ErrTransactionDeclined = errors.New("transaction declined") // A payment transaction was declined (402 or transaction_error set).
+	ErrServerError         = errors.New("server error")         // The API failed to handle the request (5xx).
+)
+
+// errorCodeKinds maps error codes of the API to the sentinel errors classifying them.
+// A mapped code takes precedence over the HTTP status of the error response.
+var errorCodeKinds = map[int]error{
+	1:  ErrInvalidRequest, // Missing parameter(s).
+	2:  ErrInvalidRequest, // Invalid parameter(s).
+	5:  ErrNotFound,       // Account not found.
+	6:  ErrInvalidRequest, // Duplicate handle.
+	7:  ErrNotFound,       // Subscription not found.
+	10: ErrNotFound,       // Customer not found.
+	11: ErrNotFound,       // Subscription plan not found.
+}
+
 type ErrorResponse struct {
 	Code             int    `json:"code"`
 	ErrorMessage     string `json:"error"`
@@ -10,13 +49,98 @@ type ErrorResponse struct {
 	Timestamp        string `json:"timestamp"`
 	RequestID        string `json:"request_id"`
 	TransactionError string `json:"transaction_error"`
+	IdempotencyKey   string `json:"-"` // Idempotency key the request was sent with, empty for requests not changing state.
+
+	RawBody []byte      `json:"-"` // Raw response body, e.g. a gateway HTML page if the body is not JSON.
+	Header  http.Header `json:"-"` // Response headers.
 }
 
 func (e ErrorResponse) Error() string {
 	message := e.ErrorMessage
+	if message == "" {
+		return fmt.Sprintf("unknown error, status code: %d", e.HTTPStatus)
+	}
 	if e.ErrorDescription != "" {
 		message += ": " + e.ErrorDescription
 	}
 
 	return message
 }
+
+// Kind returns the sentinel error classifying the error response, or nil if it cannot be classified.
+// A declined transaction takes precedence over the error code of the API,
+// which takes precedence over the HTTP status.
+func (e ErrorResponse) Kind() error {
+	if e.TransactionError != "" {
+		return ErrTransactionDeclined
+	}
+	if kind, ok := errorCodeKinds[e.Code]; ok {
+		return kind
+	}
+
+	switch {
+	case e.HTTPStatus == http.StatusPaymentRequired:
+		return ErrTransactionDeclined
+	case e.HTTPStatus == http.StatusNotFound:
+		return ErrNotFound
+	case e.HTTPStatus == http.StatusUnauthorized || e.HTTPStatus == http.StatusForbidden:
+		return ErrAuthentication
+	case e.HTTPStatus == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.HTTPStatus >= http.StatusInternalServerError:
+		return ErrServerError
+	case e.HTTPStatus >= http.StatusBadRequest:
+		return ErrInvalidRequest
+	}
+
+	return nil
+}
+
+// Is reports whether target is the sentinel error classifying the error response.
+func (e ErrorResponse) Is(target error) bool {
+	kind := e.Kind()
+	return kind != nil && kind == target
+}
+
+// As sets target to the error response if target is an *ErrorResponse,
+// so errors.As works with both ErrorResponse and *ErrorResponse targets.
+func (e *ErrorResponse) As(target interface{}) bool {
+	if t, ok := target.(*ErrorResponse); ok {
+		*t = *e
+		return true
+	}
+
+	return false
+}
+
+// newErrorResponse creates an error response from a failed HTTP response.
+// The body is decoded if it is JSON and kept as raw body in any case.
+func newErrorResponse(res *http.Response) *ErrorResponse {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodyBytes))
+
+	var errRes ErrorResponse
+	_ = json.Unmarshal(body, &errRes)
+
+	errRes.RawBody = body
+	errRes.Header = res.Header
+	if errRes.HTTPStatus == 0 {
+		errRes.HTTPStatus = res.StatusCode
+	}
+	if errRes.HTTPReason == "" {
+		errRes.HTTPReason = http.StatusText(res.StatusCode)
+	}
+	if errRes.RequestID == "" {
+		errRes.RequestID = requestID(res.Header)
+	}
+
+	return &errRes
+}
+
+// requestID returns the request id of a response from its headers.
+func requestID(header http.Header) string {
+	if id := header.Get("Request-Id"); id != "" {
+		return id
+	}
+
+	return header.Get("X-Request-Id")
+}
@@ -0,0 +1,83 @@
+package optimize
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrorResponseKind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  ErrorResponse
+		want error
+	}{
+		{"transaction error over code and status", ErrorResponse{TransactionError: "declined_by_acquirer", Code: 10, HTTPStatus: http.StatusBadRequest}, ErrTransactionDeclined},
+		{"code over status", ErrorResponse{Code: 10, HTTPStatus: http.StatusBadRequest}, ErrNotFound},
+		{"invalid parameter code", ErrorResponse{Code: 2, HTTPStatus: http.StatusNotFound}, ErrInvalidRequest},
+		{"unknown code falls back to status", ErrorResponse{Code: 999, HTTPStatus: http.StatusUnauthorized}, ErrAuthentication},
+		{"payment required", ErrorResponse{HTTPStatus: http.StatusPaymentRequired}, ErrTransactionDeclined},
+		{"not found", ErrorResponse{HTTPStatus: http.StatusNotFound}, ErrNotFound},
+		{"forbidden", ErrorResponse{HTTPStatus: http.StatusForbidden}, ErrAuthentication},
+		{"rate limited", ErrorResponse{HTTPStatus: http.StatusTooManyRequests}, ErrRateLimited},
+		{"server error", ErrorResponse{HTTPStatus: http.StatusBadGateway}, ErrServerError},
+		{"bad request", ErrorResponse{HTTPStatus: http.StatusConflict}, ErrInvalidRequest},
+		{"unclassified", ErrorResponse{HTTPStatus: http.StatusMultipleChoices}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Kind(); got != tt.want {
+				t.Errorf("Kind() = %v, want %v", got, tt.want)
+			}
+			if tt.want != nil && !errors.Is(&tt.err, tt.want) {
+				t.Errorf("errors.Is(%v) = false", tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorResponseAs(t *testing.T) {
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "request-1")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":10,"error":"Customer not found"}`))
+	})
+
+	_, err := b.GetCustomer(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetCustomer() error = %v, want %v", err, ErrNotFound)
+	}
+
+	var pointer *ErrorResponse
+	if !errors.As(err, &pointer) || pointer.Code != 10 {
+		t.Errorf("errors.As(*ErrorResponse) = %+v", pointer)
+	}
+
+	var value ErrorResponse
+	if !errors.As(err, &value) || value.Code != 10 {
+		t.Errorf("errors.As(ErrorResponse) = %+v", value)
+	}
+
+	if value.HTTPStatus != http.StatusNotFound || value.RequestID != "request-1" || string(value.RawBody) == "" {
+		t.Errorf("error response = %+v, want status, request id and raw body", value)
+	}
+}
+
+func TestErrorResponseNotJSON(t *testing.T) {
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`<html>Bad Gateway</html>`))
+	})
+
+	_, err := b.GetCustomer(context.Background(), "c-1")
+
+	var errRes *ErrorResponse
+	if !errors.As(err, &errRes) {
+		t.Fatalf("GetCustomer() error = %v, want *ErrorResponse", err)
+	}
+	if !errors.Is(err, ErrServerError) || string(errRes.RawBody) != `<html>Bad Gateway</html>` {
+		t.Errorf("error response = %+v, want server error with raw body", errRes)
+	}
+}
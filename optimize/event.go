@@ -0,0 +1,152 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// EventType represents the type of event sent by webhooks and returned by the events API.
+type EventType string
+
+const (
+	EventTypeInvoiceCreated                   EventType = "invoice_created"                     // Invoice has been created.
+	EventTypeInvoiceSettled                   EventType = "invoice_settled"                     // Invoice has been settled.
+	EventTypeInvoiceAuthorized                EventType = "invoice_authorized"                  // Invoice amount has been authorized.
+	EventTypeInvoiceCancelled                 EventType = "invoice_cancelled"                   // Invoice has been cancelled.
+	EventTypeInvoiceFailed                    EventType = "invoice_failed"                      // Invoice has failed.
+	EventTypeInvoiceRefund                    EventType = "invoice_refund"                      // Invoice has been refunded.
+	EventTypeInvoiceReactivate                EventType = "invoice_reactivate"                  // Invoice has been reactivated.
+	EventTypeInvoiceDunning                   EventType = "invoice_dunning"                     // Invoice has entered dunning.
+	EventTypeInvoiceDunningNotification       EventType = "invoice_dunning_notification"        // Dunning notification has been sent.
+	EventTypeInvoiceDunningCancelled          EventType = "invoice_dunning_cancelled"           // Dunning has been cancelled.
+	EventTypeInvoiceCredited                  EventType = "invoice_credited"                    // Invoice has been credited.
+	EventTypeInvoiceChanged                   EventType = "invoice_changed"                     // Invoice has been changed.
+	EventTypeSubscriptionCreated              EventType = "subscription_created"                // Subscription has been created.
+	EventTypeSubscriptionPaymentMethodAdded   EventType = "subscription_payment_method_added"   // Payment method has been added to a subscription.
+	EventTypeSubscriptionPaymentMethodChanged EventType = "subscription_payment_method_changed" // Payment method of a subscription has been changed.
+	EventTypeSubscriptionTrialEnd             EventType = "subscription_trial_end"              // Trial period of a subscription has ended.
+	EventTypeSubscriptionRenewal              EventType = "subscription_renewal"                // Subscription has been renewed.
+	EventTypeSubscriptionCancelled            EventType = "subscription_cancelled"              // Subscription has been cancelled.
+	EventTypeSubscriptionUncancelled          EventType = "subscription_uncancelled"            // Subscription cancellation has been reverted.
+	EventTypeSubscriptionOnHold               EventType = "subscription_on_hold"                // Subscription has been put on hold.
+	EventTypeSubscriptionOnHoldDunning        EventType = "subscription_on_hold_dunning"        // Subscription has been put on hold due to dunning.
+	EventTypeSubscriptionReactivated          EventType = "subscription_reactivated"            // Subscription has been reactivated.
+	EventTypeSubscriptionExpired              EventType = "subscription_expired"                // Subscription has expired.
+	EventTypeSubscriptionExpiredDunning       EventType = "subscription_expired_dunning"        // Subscription has expired due to dunning.
+	EventTypeSubscriptionChanged              EventType = "subscription_changed"                // Subscription has been changed.
+	EventTypeCustomerCreated                  EventType = "customer_created"                    // Customer has been created.
+	EventTypeCustomerPaymentMethodAdded       EventType = "customer_payment_method_added"       // Payment method has been added to a customer.
+	EventTypeCustomerPaymentMethodChanged     EventType = "customer_payment_method_changed"     // Payment method of a customer has been changed.
+	EventTypeCustomerChanged                  EventType = "customer_changed"                    // Customer has been changed.
+	EventTypeCustomerDeleted                  EventType = "customer_deleted"                    // Customer has been deleted.
+)
+
+// EventRange represents the range for retrieving events.
+type EventRange string
+
+const (
+	EventRangeCreated EventRange = "created" // Retrieve events by creation date.
+)
+
+// Event defines the structure for an event.
+// Only the fields relevant for the event type are set.
+type Event struct {
+	// Event id.
+	ID string `json:"id"`
+
+	// Type of the event.
+	EventType EventType `json:"event_type"`
+
+	// Customer handle.
+	Customer string `json:"customer,omitempty"`
+
+	// Payment method id for payment method events.
+	PaymentMethod string `json:"payment_method,omitempty"`
+
+	// Optional reference given when the payment method was added.
+	PaymentMethodReference string `json:"payment_method_reference,omitempty"`
+
+	// Subscription handle for subscription events and subscription invoices.
+	Subscription string `json:"subscription,omitempty"`
+
+	// Invoice handle for invoice events.
+	Invoice string `json:"invoice,omitempty"`
+
+	// Transaction id for invoice events involving a transaction.
+	Transaction string `json:"transaction,omitempty"`
+
+	// Credit note id for invoice events involving a credit note.
+	CreditNote string `json:"credit_note,omitempty"`
+
+	// Credit id for invoice events involving a credit.
+	Credit string `json:"credit,omitempty"`
+
+	// Date when the event was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// ListOfEventsResponse contains the response for listing events.
+type ListOfEventsResponse struct {
+	Size          int        `json:"size"`            // Number of events returned.
+	Count         int        `json:"count"`           // Total count of events.
+	To            string     `json:"to"`              // End of the range.
+	From          string     `json:"from"`            // Start of the range.
+	Content       []*Event   `json:"content"`         // List of events.
+	Range         EventRange `json:"range"`           // Event range.
+	NextPageToken string     `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the events of the page.
+func (r *ListOfEventsResponse) Items() []*Event {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of events.
+func (r *ListOfEventsResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// GetListOfEvents retrieves a list of events based on the provided query parameters.
+func (b *Billwerk) GetListOfEvents(ctx context.Context, params ...QueryParamFunc) (*ListOfEventsResponse, error) {
+	endpoint := "/list/event"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfEventsResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetEvent retrieves a specific event by its id.
+func (b *Billwerk) GetEvent(ctx context.Context, id string) (*Event, error) {
+	endpoint := fmt.Sprintf("/event/%s", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Event
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,267 @@
+package optimize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// eventCheckpointTimeFormat is the format used for the from query parameter when polling events.
+// The offset is always included, as times without offset are read in the time zone of the account.
+const eventCheckpointTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// eventSortCreated requests events in ascending order of creation.
+const eventSortCreated = "created"
+
+// ErrEventsOutOfOrder is returned by EventConsumer.Poll if the API returns events
+// created before events of a previous page, which would otherwise be skipped.
+var ErrEventsOutOfOrder = errors.New("events out of order")
+
+// EventCheckpoint is the position of an EventConsumer in the stream of events.
+type EventCheckpoint struct {
+	// Creation date of the last delivered event.
+	Created time.Time `json:"created"`
+
+	// Ids of the delivered events created at exactly Created.
+	// Needed to not deliver events sharing the same creation date twice.
+	EventIDs []string `json:"event_ids"`
+}
+
+// EventCheckpointStore persists the checkpoint of an EventConsumer between restarts.
+type EventCheckpointStore interface {
+	// LoadCheckpoint returns the last saved checkpoint, or nil if none has been saved yet.
+	LoadCheckpoint(ctx context.Context) (*EventCheckpoint, error)
+
+	// SaveCheckpoint saves the checkpoint.
+	SaveCheckpoint(ctx context.Context, checkpoint *EventCheckpoint) error
+}
+
+// MemoryEventCheckpointStore is an EventCheckpointStore keeping the checkpoint in memory.
+// It does not survive restarts and is mainly useful for tests.
+type MemoryEventCheckpointStore struct {
+	mu         sync.Mutex
+	checkpoint *EventCheckpoint
+}
+
+// LoadCheckpoint returns a copy of the checkpoint held in memory.
+func (s *MemoryEventCheckpointStore) LoadCheckpoint(_ context.Context) (*EventCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.checkpoint == nil {
+		return nil, nil
+	}
+
+	checkpoint := *s.checkpoint
+	checkpoint.EventIDs = slices.Clone(s.checkpoint.EventIDs)
+
+	return &checkpoint, nil
+}
+
+// SaveCheckpoint keeps a copy of the checkpoint in memory.
+func (s *MemoryEventCheckpointStore) SaveCheckpoint(_ context.Context, checkpoint *EventCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := *checkpoint
+	c.EventIDs = slices.Clone(checkpoint.EventIDs)
+	s.checkpoint = &c
+
+	return nil
+}
+
+// EventHandlerFunc is a callback for an event delivered by an EventConsumer.
+// Returning an error stops the consumer before the checkpoint is moved past the event,
+// so the event is delivered again on the next poll.
+type EventHandlerFunc func(ctx context.Context, event *Event) error
+
+// EventConsumer polls the events API and delivers events in order of creation to a handler,
+// continuing from a persisted checkpoint.
+//
+// The checkpoint is saved after each delivered event. An event is only delivered again
+// if the handler fails or the checkpoint could not be saved after the handler succeeded.
+type EventConsumer struct {
+	billwerk *Billwerk
+	store    EventCheckpointStore
+	handler  EventHandlerFunc
+	interval time.Duration
+	pageSize int
+	start    time.Time
+}
+
+// EventConsumerOption is a function that sets options for the EventConsumer configuration.
+type EventConsumerOption func(consumer *EventConsumer)
+
+// WithEventPollInterval allows setting the interval between polls in Run. Default is 30 seconds.
+func WithEventPollInterval(interval time.Duration) EventConsumerOption {
+	return func(consumer *EventConsumer) {
+		consumer.interval = interval
+	}
+}
+
+// WithEventPageSize allows setting the number of events requested per page. Default is 100.
+func WithEventPageSize(size int) EventConsumerOption {
+	return func(consumer *EventConsumer) {
+		consumer.pageSize = size
+	}
+}
+
+// WithEventStart allows setting where to start if no checkpoint has been saved yet.
+// Default is the time the consumer is created, so past events are not delivered.
+func WithEventStart(start time.Time) EventConsumerOption {
+	return func(consumer *EventConsumer) {
+		consumer.start = start
+	}
+}
+
+// NewEventConsumer creates a new EventConsumer delivering events to handler
+// and persisting its checkpoint in store.
+func NewEventConsumer(billwerk *Billwerk, store EventCheckpointStore, handler EventHandlerFunc, opts ...EventConsumerOption) *EventConsumer {
+	c := &EventConsumer{
+		billwerk: billwerk,
+		store:    store,
+		handler:  handler,
+		interval: 30 * time.Second,
+		pageSize: 100,
+		start:    time.Now(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Run polls for new events until the context is cancelled or polling fails.
+func (c *EventConsumer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches the events created since the checkpoint page by page and delivers them to the handler
+// in order of creation. It returns the first error of the handler, the store or the API.
+// Events delivered before an error are not delivered again.
+//
+// Events are requested in ascending order of creation and every page is sorted by creation date and id.
+// If a page contains events created before the events of a previous page, Poll returns ErrEventsOutOfOrder
+// instead of skipping them.
+func (c *EventConsumer) Poll(ctx context.Context) error {
+	checkpoint, err := c.store.LoadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		checkpoint = &EventCheckpoint{Created: c.start}
+	}
+
+	params := []QueryParamFunc{
+		WithQueryParam(Range, EventRangeCreated),
+		WithQueryParam(From, checkpoint.Created.Format(eventCheckpointTimeFormat)),
+		WithQueryParam(Sort, eventSortCreated),
+		WithQueryParam(Size, c.pageSize),
+	}
+	baseLen := len(params)
+
+	var last *Event
+	for {
+		page, err := c.billwerk.GetListOfEvents(ctx, params...)
+		if err != nil {
+			return err
+		}
+
+		events, err := sortEvents(page.Items())
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 && last != nil && events[0].Created.Before(*last.Created) {
+			return fmt.Errorf("%w: event %s created before event %s", ErrEventsOutOfOrder, events[0].ID, last.ID)
+		}
+
+		for _, event := range events {
+			last = event
+			if !checkpoint.isAfter(event) {
+				continue
+			}
+
+			if err = c.handler(ctx, event); err != nil {
+				return err
+			}
+
+			checkpoint.advance(event)
+			if err = c.store.SaveCheckpoint(ctx, checkpoint); err != nil {
+				return err
+			}
+		}
+
+		if page.NextPage() == "" || len(events) == 0 {
+			return nil
+		}
+		params = append(params[:baseLen:baseLen], WithQueryParam(NextPageToken, page.NextPage()))
+	}
+}
+
+// sortEvents returns a copy of the events sorted by creation date and id.
+func sortEvents(events []*Event) ([]*Event, error) {
+	for _, event := range events {
+		if event.Created == nil {
+			return nil, fmt.Errorf("event without creation date: %s", event.ID)
+		}
+	}
+
+	sorted := slices.Clone(events)
+	slices.SortFunc(sorted, compareEvents)
+
+	return sorted, nil
+}
+
+// compareEvents orders events by creation date and id.
+func compareEvents(a, b *Event) int {
+	if c := a.Created.Compare(*b.Created); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.ID, b.ID)
+}
+
+// isAfter reports whether the event has not been delivered according to the checkpoint.
+func (c *EventCheckpoint) isAfter(event *Event) bool {
+	switch event.Created.Compare(c.Created) {
+	case 1:
+		return true
+	case 0:
+		return !slices.Contains(c.EventIDs, event.ID)
+	default:
+		return false
+	}
+}
+
+// advance moves the checkpoint to the delivered event.
+func (c *EventCheckpoint) advance(event *Event) {
+	if event.Created.Equal(c.Created) {
+		c.EventIDs = append(c.EventIDs, event.ID)
+		return
+	}
+
+	c.Created = *event.Created
+	c.EventIDs = []string{event.ID}
+}
@@ -0,0 +1,190 @@
+package optimize
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// eventPages serves pages of events keyed by next page token, the first page has the empty token.
+type eventPages map[string][]*Event
+
+func (p eventPages) serve(t *testing.T, queries *[]map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/list/event" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		query := make(map[string]string)
+		for key := range r.URL.Query() {
+			query[key] = r.URL.Query().Get(key)
+		}
+		if queries != nil {
+			*queries = append(*queries, query)
+		}
+
+		token := query["next_page_token"]
+		res := ListOfEventsResponse{Content: p[token]}
+		if _, ok := p[token+"next"]; ok {
+			res.NextPageToken = token + "next"
+		}
+
+		_ = json.NewEncoder(w).Encode(res)
+	}
+}
+
+func newTestEvent(id string, created time.Time) *Event {
+	return &Event{ID: id, Created: &created}
+}
+
+// recordEvents returns a handler recording the ids of delivered events.
+func recordEvents(ids *[]string) EventHandlerFunc {
+	return func(_ context.Context, event *Event) error {
+		*ids = append(*ids, event.ID)
+		return nil
+	}
+}
+
+func TestEventConsumerPollSortsPage(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pages := eventPages{"": {
+		newTestEvent("e3", start.Add(3*time.Second)),
+		newTestEvent("e2b", start.Add(2*time.Second)),
+		newTestEvent("e2a", start.Add(2*time.Second)),
+		newTestEvent("e1", start.Add(time.Second)),
+	}}
+
+	var queries []map[string]string
+	b := newTestBillwerk(t, pages.serve(t, &queries))
+
+	var ids []string
+	store := &MemoryEventCheckpointStore{}
+	consumer := NewEventConsumer(b, store, recordEvents(&ids), WithEventStart(start), WithEventPageSize(10))
+
+	if err := consumer.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if want := []string{"e1", "e2a", "e2b", "e3"}; !slices.Equal(ids, want) {
+		t.Errorf("delivered events = %v, want %v", ids, want)
+	}
+
+	checkpoint, _ := store.LoadCheckpoint(context.Background())
+	if !checkpoint.Created.Equal(start.Add(3*time.Second)) || !slices.Equal(checkpoint.EventIDs, []string{"e3"}) {
+		t.Errorf("checkpoint = %+v, want e3", checkpoint)
+	}
+
+	query := queries[0]
+	if query["sort"] != "created" || query["range"] != "created" || query["size"] != "10" {
+		t.Errorf("query = %v, want sort, range and size", query)
+	}
+	if query["from"] != "2024-01-01T12:00:00.000Z" {
+		t.Errorf("from = %q, want %q", query["from"], "2024-01-01T12:00:00.000Z")
+	}
+
+	// The API returns events created at the checkpoint again, they must not be delivered twice.
+	ids = nil
+	if err := consumer.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("delivered events on second poll = %v, want none", ids)
+	}
+	if queries[1]["from"] != "2024-01-01T12:00:03.000Z" {
+		t.Errorf("from = %q, want checkpoint", queries[1]["from"])
+	}
+}
+
+func TestEventConsumerPollSameCreationDate(t *testing.T) {
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pages := eventPages{"": {newTestEvent("b", created), newTestEvent("c", created)}}
+	b := newTestBillwerk(t, pages.serve(t, nil))
+
+	store := &MemoryEventCheckpointStore{}
+	_ = store.SaveCheckpoint(context.Background(), &EventCheckpoint{Created: created, EventIDs: []string{"a", "b"}})
+
+	var ids []string
+	if err := NewEventConsumer(b, store, recordEvents(&ids)).Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if want := []string{"c"}; !slices.Equal(ids, want) {
+		t.Errorf("delivered events = %v, want %v", ids, want)
+	}
+
+	checkpoint, _ := store.LoadCheckpoint(context.Background())
+	if want := []string{"a", "b", "c"}; !slices.Equal(checkpoint.EventIDs, want) {
+		t.Errorf("checkpoint event ids = %v, want %v", checkpoint.EventIDs, want)
+	}
+}
+
+func TestEventConsumerPollPages(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pages := eventPages{
+		"":     {newTestEvent("e1", start.Add(time.Second))},
+		"next": {newTestEvent("e2", start.Add(2*time.Second))},
+	}
+	b := newTestBillwerk(t, pages.serve(t, nil))
+
+	var ids []string
+	if err := NewEventConsumer(b, &MemoryEventCheckpointStore{}, recordEvents(&ids), WithEventStart(start)).Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if want := []string{"e1", "e2"}; !slices.Equal(ids, want) {
+		t.Errorf("delivered events = %v, want %v", ids, want)
+	}
+}
+
+func TestEventConsumerPollOutOfOrder(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pages := eventPages{
+		"":     {newTestEvent("e3", start.Add(3*time.Second))},
+		"next": {newTestEvent("e1", start.Add(time.Second))},
+	}
+	b := newTestBillwerk(t, pages.serve(t, nil))
+
+	var ids []string
+	err := NewEventConsumer(b, &MemoryEventCheckpointStore{}, recordEvents(&ids), WithEventStart(start)).Poll(context.Background())
+	if !errors.Is(err, ErrEventsOutOfOrder) {
+		t.Fatalf("Poll() error = %v, want %v", err, ErrEventsOutOfOrder)
+	}
+	if want := []string{"e3"}; !slices.Equal(ids, want) {
+		t.Errorf("delivered events = %v, want %v", ids, want)
+	}
+}
+
+func TestEventConsumerPollHandlerError(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pages := eventPages{"": {
+		newTestEvent("e1", start.Add(time.Second)),
+		newTestEvent("e2", start.Add(2*time.Second)),
+	}}
+	b := newTestBillwerk(t, pages.serve(t, nil))
+
+	errHandler := errors.New("handler failed")
+	var ids []string
+	fail := true
+	handler := func(_ context.Context, event *Event) error {
+		if event.ID == "e2" && fail {
+			fail = false
+			return errHandler
+		}
+		ids = append(ids, event.ID)
+		return nil
+	}
+
+	consumer := NewEventConsumer(b, &MemoryEventCheckpointStore{}, handler, WithEventStart(start))
+	if err := consumer.Poll(context.Background()); !errors.Is(err, errHandler) {
+		t.Fatalf("Poll() error = %v, want %v", err, errHandler)
+	}
+	if err := consumer.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if want := []string{"e1", "e2"}; !slices.Equal(ids, want) {
+		t.Errorf("delivered events = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,158 @@
+package optimize
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// idempotencyKeyContextKey is the context key for an explicit idempotency key.
+type idempotencyKeyContextKey struct{}
+
+// ErrIdempotencyKeyConflict is returned if the idempotency key of a context, see WithIdempotencyKey,
+// is used for a call other than the one it is bound to. The call is not sent.
+var ErrIdempotencyKeyConflict = errors.New("idempotency key is bound to another call")
+
+// idempotencyKeyScope is an explicit idempotency key bound to the first call made with it.
+type idempotencyKeyScope struct {
+	key string
+
+	mu    sync.Mutex
+	bound string
+}
+
+// claim returns the key if the request targets the call the key is bound to.
+// The key is bound to the method and endpoint of the first request claiming it.
+func (s *idempotencyKeyScope) claim(req *http.Request) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	call := req.Method + " " + req.URL.Path
+	if s.bound == "" {
+		s.bound = call
+	}
+	if s.bound != call {
+		return "", fmt.Errorf("%w: key %s is bound to %s, not %s", ErrIdempotencyKeyConflict, s.key, s.bound, call)
+	}
+
+	return s.key, nil
+}
+
+// WithIdempotencyKey returns a copy of ctx carrying an idempotency key for the mutating call made with it.
+// Billwerk does not perform a call twice for the same key, so the key should identify one logical call,
+// e.g. a job id, and be reused when the call is replayed after a crash.
+//
+// The key is bound to the method and endpoint of the first mutating call made with ctx or a context
+// derived from it. Calls of the same method and endpoint, e.g. a replay, send the same key.
+// Mutating calls of other methods or endpoints fail with ErrIdempotencyKeyConflict without being sent.
+// Derive a new context with its own key for every logical call. Two different calls to the same endpoint,
+// e.g. two charges, would otherwise share the key and the second call would be treated as a replay of the first.
+//
+// Example:
+//
+//	charge, err := b.CreateCharge(WithIdempotencyKey(ctx, "charge-"+orderID), &ChargeCreate{...})
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, &idempotencyKeyScope{key: key})
+}
+
+// IdempotencyKeyFromContext returns the idempotency key carried by ctx, if any.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	scope, ok := ctx.Value(idempotencyKeyContextKey{}).(*idempotencyKeyScope)
+	if !ok || scope.key == "" {
+		return "", false
+	}
+
+	return scope.key, true
+}
+
+// IdempotencyError wraps an error of a mutating call with the idempotency key the call was sent with.
+// Reuse the key to replay the call safely, e.g. after a timeout.
+type IdempotencyError struct {
+	// Idempotency key the call was sent with.
+	Key string
+
+	// Error of the call.
+	Err error
+}
+
+// Error returns the message of the wrapped error.
+func (e *IdempotencyError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *IdempotencyError) Unwrap() error {
+	return e.Err
+}
+
+// IdempotencyKeyFromError returns the idempotency key of the mutating call that failed with err, if any.
+func IdempotencyKeyFromError(err error) (string, bool) {
+	var errRes *ErrorResponse
+	if errors.As(err, &errRes) && errRes.IdempotencyKey != "" {
+		return errRes.IdempotencyKey, true
+	}
+
+	var idempotencyErr *IdempotencyError
+	if errors.As(err, &idempotencyErr) && idempotencyErr.Key != "" {
+		return idempotencyErr.Key, true
+	}
+
+	return "", false
+}
+
+// withIdempotencyKey wraps the error of a mutating call so the idempotency key can be recovered
+// with IdempotencyKeyFromError. An *ErrorResponse already carries the key and is returned as is.
+func withIdempotencyKey(err error, key string) error {
+	if err == nil || key == "" {
+		return err
+	}
+
+	var errRes *ErrorResponse
+	if errors.As(err, &errRes) {
+		return err
+	}
+
+	return &IdempotencyError{Key: key, Err: err}
+}
+
+// NewIdempotencyKey generates a new random idempotency key in the form of a version 4 UUID.
+func NewIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// ensureIdempotencyKey sets the Idempotency-Key header of a mutating request and returns the key.
+// The header is kept if already set, otherwise the key of the request context is used, or a new key
+// is generated if the context carries none. The same key is then sent with all retries of the request.
+// No key is set for requests not changing state.
+// It returns ErrIdempotencyKeyConflict if the key of the context is bound to another call.
+func ensureIdempotencyKey(req *http.Request) (string, error) {
+	if key := req.Header.Get(idempotencyKeyHeader); key != "" {
+		return key, nil
+	}
+
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch:
+	default:
+		return "", nil
+	}
+
+	key := NewIdempotencyKey()
+	if scope, ok := req.Context().Value(idempotencyKeyContextKey{}).(*idempotencyKeyScope); ok && scope.key != "" {
+		var err error
+		if key, err = scope.claim(req); err != nil {
+			return "", err
+		}
+	}
+	req.Header.Set(idempotencyKeyHeader, key)
+
+	return key, nil
+}
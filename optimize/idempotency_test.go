@@ -0,0 +1,138 @@
+package optimize
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordIdempotencyKeys returns a handler recording the idempotency keys of requests by path.
+func recordIdempotencyKeys(mu *sync.Mutex, keys map[string][]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.URL.Path] = append(keys[r.URL.Path], r.Header.Get(idempotencyKeyHeader))
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+func TestIdempotencyKeyFromContext(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string][]string)
+	b := newTestBillwerk(t, recordIdempotencyKeys(&mu, keys))
+
+	ctx := WithIdempotencyKey(context.Background(), "job-1")
+	for range 2 {
+		if _, err := b.CreateCustomer(ctx, &Customer{Handle: "c-1"}); err != nil {
+			t.Fatalf("CreateCustomer() error = %v", err)
+		}
+	}
+
+	if got := keys["/v1/customer"]; len(got) != 2 || got[0] != "job-1" || got[1] != "job-1" {
+		t.Errorf("idempotency keys = %v, want job-1 twice", got)
+	}
+}
+
+func TestIdempotencyKeyConflict(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string][]string)
+	b := newTestBillwerk(t, recordIdempotencyKeys(&mu, keys))
+
+	ctx := WithIdempotencyKey(context.Background(), "job-1")
+	if _, err := b.CreateCustomer(ctx, &Customer{Handle: "c-1"}); err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+
+	_, err := b.CreateSubscription(ctx, &SubscriptionCreate{Customer: "c-1", Plan: "gold"})
+	if !errors.Is(err, ErrIdempotencyKeyConflict) {
+		t.Fatalf("CreateSubscription() error = %v, want %v", err, ErrIdempotencyKeyConflict)
+	}
+	if got := keys["/v1/subscription"]; len(got) != 0 {
+		t.Errorf("subscription sent with keys %v, want not sent", got)
+	}
+
+	// Reads do not use the key.
+	if _, err = b.GetCustomer(ctx, "c-1"); err != nil {
+		t.Errorf("GetCustomer() error = %v", err)
+	}
+}
+
+func TestIdempotencyKeyGenerated(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string][]string)
+	b := newTestBillwerk(t, recordIdempotencyKeys(&mu, keys))
+
+	for range 2 {
+		if _, err := b.CreateCustomer(context.Background(), &Customer{Handle: "c-1"}); err != nil {
+			t.Fatalf("CreateCustomer() error = %v", err)
+		}
+	}
+	if _, err := b.GetCustomer(context.Background(), "c-1"); err != nil {
+		t.Fatalf("GetCustomer() error = %v", err)
+	}
+
+	got := keys["/v1/customer"]
+	if len(got) != 2 || got[0] == "" || got[0] == got[1] {
+		t.Errorf("idempotency keys = %v, want two different keys", got)
+	}
+	if got = keys["/v1/customer/c-1"]; len(got) != 1 || got[0] != "" {
+		t.Errorf("idempotency keys of read = %v, want none", got)
+	}
+}
+
+func TestIdempotencyKeyRetries(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		attempt := len(keys)
+		mu.Unlock()
+
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+
+	if _, err := b.CreateCustomer(context.Background(), &Customer{Handle: "c-1"}); err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("idempotency keys = %v, want the same key for both attempts", keys)
+	}
+}
+
+func TestIdempotencyKeyFromError(t *testing.T) {
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":2,"error":"Invalid parameter"}`))
+	})
+
+	ctx := WithIdempotencyKey(context.Background(), "job-1")
+	_, err := b.CreateCustomer(ctx, &Customer{Handle: "c-1"})
+	if key, ok := IdempotencyKeyFromError(err); !ok || key != "job-1" {
+		t.Errorf("IdempotencyKeyFromError() = %q, %v, want job-1", key, ok)
+	}
+
+	// Transport errors carry the key as well.
+	b = New("api-key", WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset")
+	})}))
+	_, err = b.CreateCustomer(WithIdempotencyKey(context.Background(), "job-2"), &Customer{Handle: "c-1"})
+	if key, ok := IdempotencyKeyFromError(err); !ok || key != "job-2" {
+		t.Errorf("IdempotencyKeyFromError() = %q, %v, want job-2", key, ok)
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper calling the function.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
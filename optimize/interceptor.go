@@ -0,0 +1,67 @@
+package optimize
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// RoundTripFunc sends a request and returns its response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Interceptor is a function that intercepts every request sent by Do.
+//
+// An interceptor may modify the request, e.g. add headers, before passing it to next and
+// inspect the response and error returned by next, e.g. to audit calls or measure latency.
+// If the API responds with a failure status code, next returns the response together with
+// the decoded *ErrorResponse as error. The response body can still be read in that case.
+type Interceptor func(req *http.Request, next RoundTripFunc) (*http.Response, error)
+
+// errNoResponse is returned if an interceptor returned neither a response nor an error.
+var errNoResponse = errors.New("interceptor returned no response")
+
+// WithInterceptors allows adding interceptors to the Billwerk client.
+//
+// Interceptors run in the order they are added: the first interceptor receives the request first
+// and the response last. They wrap the whole call, so retries and rate limiting happen within next.
+// The option can be used multiple times, the interceptors are appended.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(billwerk *Billwerk) {
+		billwerk.interceptors = append(billwerk.interceptors, interceptors...)
+	}
+}
+
+// intercept sends the request through the interceptor chain of the client.
+func (b *Billwerk) intercept(req *http.Request, send RoundTripFunc) (*http.Response, error) {
+	next := send
+	for i := len(b.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := b.interceptors[i], next
+		next = func(req *http.Request) (*http.Response, error) {
+			return interceptor(req, inner)
+		}
+	}
+
+	return next(req)
+}
+
+// roundTrip sends the request and decodes failure responses into an *ErrorResponse.
+// It is the innermost function of the interceptor chain.
+func (b *Billwerk) roundTrip(req *http.Request) (*http.Response, error) {
+	res, err := b.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+		errRes := newErrorResponse(res)
+		errRes.IdempotencyKey = req.Header.Get(idempotencyKeyHeader)
+
+		_ = res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(errRes.RawBody))
+
+		return res, errRes
+	}
+
+	return res, nil
+}
@@ -0,0 +1,473 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// InvoiceState represents the state of an invoice.
+type InvoiceState string
+
+const (
+	InvoiceStateCreated    InvoiceState = "created"    // Invoice is created but not yet processed.
+	InvoiceStatePending    InvoiceState = "pending"    // Invoice is awaiting payment.
+	InvoiceStateDunning    InvoiceState = "dunning"    // Invoice is in dunning.
+	InvoiceStateSettled    InvoiceState = "settled"    // Invoice is paid.
+	InvoiceStateAuthorized InvoiceState = "authorized" // Invoice amount is authorized but not settled.
+	InvoiceStateCancelled  InvoiceState = "cancelled"  // Invoice is cancelled.
+	InvoiceStateFailed     InvoiceState = "failed"     // Invoice has failed.
+)
+
+// InvoiceType represents the type of invoice.
+type InvoiceType string
+
+const (
+	InvoiceTypeSubscriptionRecurring InvoiceType = "s"   // Recurring subscription invoice.
+	InvoiceTypeSubscriptionOndemand  InvoiceType = "so"  // On demand subscription invoice.
+	InvoiceTypeSubscriptionOneTime   InvoiceType = "soi" // One-time subscription invoice.
+	InvoiceTypeCustomerOndemand      InvoiceType = "co"  // On demand customer invoice.
+	InvoiceTypeCharge                InvoiceType = "ch"  // One-off charge.
+)
+
+// InvoiceTransactionState represents the state of an invoice transaction.
+type InvoiceTransactionState string
+
+const (
+	InvoiceTransactionStatePending    InvoiceTransactionState = "pending"    // Transaction is pending.
+	InvoiceTransactionStateProcessing InvoiceTransactionState = "processing" // Transaction is being processed.
+	InvoiceTransactionStateAuthorized InvoiceTransactionState = "authorized" // Transaction is authorized.
+	InvoiceTransactionStateSettled    InvoiceTransactionState = "settled"    // Transaction is settled.
+	InvoiceTransactionStateRefunded   InvoiceTransactionState = "refunded"   // Transaction is refunded.
+	InvoiceTransactionStateFailed     InvoiceTransactionState = "failed"     // Transaction has failed.
+	InvoiceTransactionStateCancelled  InvoiceTransactionState = "cancelled"  // Transaction is cancelled.
+)
+
+// InvoiceTransactionType represents the type of an invoice transaction.
+type InvoiceTransactionType string
+
+const (
+	InvoiceTransactionTypeSettle        InvoiceTransactionType = "settle"        // Settle transaction.
+	InvoiceTransactionTypeRefund        InvoiceTransactionType = "refund"        // Refund transaction.
+	InvoiceTransactionTypeAuthorization InvoiceTransactionType = "authorization" // Authorization transaction.
+)
+
+// InvoiceManualTransactionMethod defines the payment method of a manual transaction made outside Billwerk.
+type InvoiceManualTransactionMethod string
+
+const (
+	InvoiceManualTransactionMethodOther        InvoiceManualTransactionMethod = "other"         // Other payment method.
+	InvoiceManualTransactionMethodCash         InvoiceManualTransactionMethod = "cash"          // Cash payment.
+	InvoiceManualTransactionMethodBankTransfer InvoiceManualTransactionMethod = "bank_transfer" // Bank transfer.
+	InvoiceManualTransactionMethodCard         InvoiceManualTransactionMethod = "card"          // Card payment made outside Billwerk.
+)
+
+// InvoiceRange represents the range for retrieving invoices.
+type InvoiceRange string
+
+const (
+	InvoiceRangeCreated InvoiceRange = "created" // Retrieve invoices by creation date.
+	InvoiceRangeSettled InvoiceRange = "settled" // Retrieve invoices by settle date.
+)
+
+// OrderLine defines an order line on an invoice or charge.
+type OrderLine struct {
+	// Order line id.
+	ID string `json:"id,omitempty"`
+
+	// Order line text.
+	OrderText string `json:"ordertext"`
+
+	// Total amount of the order line.
+	Amount int32 `json:"amount"`
+
+	// Vat for the order line as a fraction, e.g. 0.25.
+	Vat float64 `json:"vat,omitempty"`
+
+	// Quantity of the order line.
+	Quantity int32 `json:"quantity,omitempty"`
+
+	// Origin of the order line: plan, add_on, ondemand, additional_cost, credit, discount, setup_fee, etc.
+	Origin string `json:"origin,omitempty"`
+
+	// Handle of the origin, e.g. the add-on handle.
+	OriginHandle string `json:"origin_handle,omitempty"`
+
+	// Total amount of the order line after discounts.
+	DiscountedAmount int32 `json:"discounted_amount,omitempty"`
+
+	// Total vat amount of the order line.
+	AmountVat int32 `json:"amount_vat,omitempty"`
+
+	// Total amount of the order line excluding vat.
+	AmountExVat int32 `json:"amount_ex_vat,omitempty"`
+
+	// Unit amount of the order line.
+	UnitAmount int32 `json:"unit_amount,omitempty"`
+
+	// Unit vat amount of the order line.
+	UnitAmountVat int32 `json:"unit_amount_vat,omitempty"`
+
+	// Unit amount of the order line excluding vat.
+	UnitAmountExVat int32 `json:"unit_amount_ex_vat,omitempty"`
+
+	// Whether the amount is defined including vat.
+	AmountInclVat bool `json:"amount_incl_vat,omitempty"`
+
+	// Start of the billing period the order line covers.
+	PeriodFrom *time.Time `json:"period_from,omitempty"`
+
+	// End of the billing period the order line covers.
+	PeriodTo *time.Time `json:"period_to,omitempty"`
+
+	// Date when the order line was created. In ISO-8601 extended offset date-time format.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// InvoiceTransaction defines a payment transaction on an invoice.
+type InvoiceTransaction struct {
+	// Transaction id.
+	ID string `json:"id"`
+
+	// State of the transaction.
+	State InvoiceTransactionState `json:"state"`
+
+	// Invoice id the transaction belongs to.
+	Invoice string `json:"invoice"`
+
+	// Type of the transaction: settle, refund, authorization.
+	Type InvoiceTransactionType `json:"type"`
+
+	// Transaction amount.
+	Amount int32 `json:"amount"`
+
+	// Payment type, e.g. card, mobilepay, offline or manual.
+	PaymentType string `json:"payment_type,omitempty"`
+
+	// Error code if the transaction failed.
+	Error string `json:"error,omitempty"`
+
+	// Error state if the transaction failed: pending, soft_declined, hard_declined, processing_error.
+	ErrorState string `json:"error_state,omitempty"`
+
+	// Message from the acquirer if the transaction failed.
+	AcquirerMessage string `json:"acquirer_message,omitempty"`
+
+	// Date when the transaction was settled.
+	Settled *time.Time `json:"settled,omitempty"`
+
+	// Date when the transaction was authorized.
+	Authorized *time.Time `json:"authorized,omitempty"`
+
+	// Date when the transaction failed.
+	Failed *time.Time `json:"failed,omitempty"`
+
+	// Date when the transaction was refunded.
+	Refunded *time.Time `json:"refunded,omitempty"`
+
+	// Date when the transaction was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// Invoice defines the structure for an invoice.
+type Invoice struct {
+	// Invoice id assigned by Billwerk.
+	ID string `json:"id"`
+
+	// Per account unique handle for the invoice.
+	Handle string `json:"handle"`
+
+	// Customer handle.
+	Customer string `json:"customer"`
+
+	// Subscription handle for subscription invoices.
+	Subscription string `json:"subscription,omitempty"`
+
+	// Plan handle for subscription invoices.
+	Plan string `json:"plan,omitempty"`
+
+	// State of the invoice.
+	State InvoiceState `json:"state"`
+
+	// Type of the invoice.
+	Type InvoiceType `json:"type"`
+
+	// Invoice amount including vat.
+	Amount int32 `json:"amount"`
+
+	// Invoice number.
+	Number int32 `json:"number"`
+
+	// Currency for the invoice in ISO 4217 three letter alpha code.
+	Currency string `json:"currency"`
+
+	// Invoice amount before discounts.
+	OrgAmount int32 `json:"org_amount"`
+
+	// Discount amount.
+	DiscountAmount int32 `json:"discount_amount"`
+
+	// Vat amount.
+	AmountVat int32 `json:"amount_vat"`
+
+	// Invoice amount excluding vat.
+	AmountExVat int32 `json:"amount_ex_vat"`
+
+	// Settled amount.
+	SettledAmount int32 `json:"settled_amount"`
+
+	// Refunded amount.
+	RefundedAmount int32 `json:"refunded_amount"`
+
+	// Authorized amount.
+	AuthorizedAmount int32 `json:"authorized_amount,omitempty"`
+
+	// Credited amount.
+	CreditedAmount int32 `json:"credited_amount,omitempty"`
+
+	// Subscription period number.
+	PeriodNumber int32 `json:"period_number,omitempty"`
+
+	// Start of the billing period.
+	PeriodFrom *time.Time `json:"period_from,omitempty"`
+
+	// End of the billing period.
+	PeriodTo *time.Time `json:"period_to,omitempty"`
+
+	// Order lines of the invoice.
+	OrderLines []*OrderLine `json:"order_lines"`
+
+	// Transactions of the invoice.
+	Transactions []*InvoiceTransaction `json:"transactions"`
+
+	// Credit notes issued for the invoice.
+	CreditNotes []*CreditNote `json:"credit_notes"`
+
+	// Date when the invoice is due.
+	Due *time.Time `json:"due,omitempty"`
+
+	// Date when the invoice failed.
+	Failed *time.Time `json:"failed,omitempty"`
+
+	// Date when the invoice was settled.
+	Settled *time.Time `json:"settled,omitempty"`
+
+	// Date when the invoice was cancelled.
+	Cancelled *time.Time `json:"cancelled,omitempty"`
+
+	// Date when the invoice was authorized.
+	Authorized *time.Time `json:"authorized,omitempty"`
+
+	// Date when dunning started for the invoice.
+	DunningStart *time.Time `json:"dunning_start,omitempty"`
+
+	// Number of dunning events for the invoice.
+	DunningCount int32 `json:"dunning_count,omitempty"`
+
+	// Date when dunning expired for the invoice.
+	DunningExpired *time.Time `json:"dunning_expired,omitempty"`
+
+	// Whether the invoice is currently being processed.
+	Processing bool `json:"processing,omitempty"`
+
+	// Date when the invoice was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// InvoiceSettle defines the request for settling an invoice.
+type InvoiceSettle struct {
+	// Optional payment method to use instead of the subscription or customer payment method.
+	PaymentMethod string `json:"payment_method,omitempty"`
+}
+
+// InvoiceManualTransaction defines the request for registering a transaction made outside Billwerk.
+type InvoiceManualTransaction struct {
+	// Type of the transaction: settle or refund.
+	Type InvoiceTransactionType `json:"type"`
+
+	// Transaction amount.
+	Amount int32 `json:"amount"`
+
+	// Comment for the transaction.
+	Comment string `json:"comment,omitempty"`
+
+	// Payment method used outside Billwerk.
+	Method InvoiceManualTransactionMethod `json:"method"`
+
+	// Date of the payment. In ISO-8601 extended offset date-time format.
+	PaymentDate string `json:"payment_date"`
+}
+
+// ListOfInvoicesResponse contains the response for listing invoices.
+type ListOfInvoicesResponse struct {
+	Size          int          `json:"size"`            // Number of invoices returned.
+	Count         int          `json:"count"`           // Total count of invoices.
+	To            string       `json:"to"`              // End of the range.
+	From          string       `json:"from"`            // Start of the range.
+	Content       []*Invoice   `json:"content"`         // List of invoices.
+	Range         InvoiceRange `json:"range"`           // Invoice range.
+	NextPageToken string       `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the invoices of the page.
+func (r *ListOfInvoicesResponse) Items() []*Invoice {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of invoices.
+func (r *ListOfInvoicesResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// GetListOfInvoices retrieves a list of invoices based on the provided query parameters.
+func (b *Billwerk) GetListOfInvoices(ctx context.Context, params ...QueryParamFunc) (*ListOfInvoicesResponse, error) {
+	endpoint := "/list/invoice"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfInvoicesResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetInvoice retrieves a specific invoice by its id or handle.
+func (b *Billwerk) GetInvoice(ctx context.Context, id string) (*Invoice, error) {
+	endpoint := fmt.Sprintf("/invoice/%s", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Invoice
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SettleInvoice retries settlement of a pending or dunning invoice by its id or handle.
+// The settle parameter is optional.
+func (b *Billwerk) SettleInvoice(ctx context.Context, id string, settle *InvoiceSettle) (*Invoice, error) {
+	endpoint := fmt.Sprintf("/invoice/%s/settle", id)
+
+	if settle == nil {
+		settle = &InvoiceSettle{}
+	}
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(settle)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Invoice
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CancelInvoice cancels a pending or dunning invoice by its id or handle.
+func (b *Billwerk) CancelInvoice(ctx context.Context, id string) (*Invoice, error) {
+	endpoint := fmt.Sprintf("/invoice/%s/cancel", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Invoice
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ReactivateInvoice reactivates a cancelled or failed invoice by its id or handle.
+func (b *Billwerk) ReactivateInvoice(ctx context.Context, id string) (*Invoice, error) {
+	endpoint := fmt.Sprintf("/invoice/%s/reactivate", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Invoice
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateInvoiceManualTransaction registers a settle or refund transaction made outside Billwerk,
+// e.g. a cash payment or a bank transfer, on an invoice by its id or handle.
+func (b *Billwerk) CreateInvoiceManualTransaction(ctx context.Context, id string, transaction *InvoiceManualTransaction) (*Invoice, error) {
+	endpoint := fmt.Sprintf("/invoice/%s/manual_transaction", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(transaction)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Invoice
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CancelInvoiceTransaction cancels a pending or authorized transaction on an invoice
+// by the invoice id or handle and the transaction id.
+func (b *Billwerk) CancelInvoiceTransaction(ctx context.Context, id string, transactionID string) (*InvoiceTransaction, error) {
+	endpoint := fmt.Sprintf("/invoice/%s/transaction/%s/cancel", id, transactionID)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res InvoiceTransaction
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,197 @@
+package optimize
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// redacted replaces redacted values in logs.
+const redacted = "[REDACTED]"
+
+// maxLoggedBodyBytes is the maximum number of bytes of a request or response body that is logged.
+const maxLoggedBodyBytes = 4 << 10
+
+// maxRedactedBodyBytes is the maximum number of bytes of a body read for redaction.
+// Larger bodies are omitted, as truncated JSON cannot be redacted.
+const maxRedactedBodyBytes = 64 << 10
+
+// DefaultRedactedFields are the JSON fields with personal data that are redacted from logged bodies
+// unless other fields are set with WithRedactedFields.
+var DefaultRedactedFields = []string{
+	"email",
+	"alert_emails",
+	"first_name",
+	"last_name",
+	"company",
+	"address",
+	"address2",
+	"city",
+	"postal_code",
+	"phone",
+	"vat",
+}
+
+// secretFields are the JSON fields that are always redacted from logged bodies.
+var secretFields = []string{
+	"api_key",
+	"password",
+	"secret",
+}
+
+// WithLogger allows setting a logger for the Billwerk client.
+//
+// Every attempt of a request is logged with the method, endpoint, status, duration, attempt
+// and request id. Successful attempts are logged at info level, failed ones at warn or error level.
+// If the logger is enabled for debug level, the request headers and the request and response bodies
+// are logged as well. The Authorization header, the API key, secrets and the redacted fields,
+// see WithRedactedFields, are never logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(billwerk *Billwerk) {
+		billwerk.logger = logger
+	}
+}
+
+// WithRedactedFields allows setting the JSON fields that are redacted from logged bodies,
+// replacing DefaultRedactedFields. Fields are matched case-insensitively at any depth.
+func WithRedactedFields(fields ...string) Option {
+	return func(billwerk *Billwerk) {
+		billwerk.redactedFields = fields
+	}
+}
+
+// logAttempt logs a single attempt of a request. If the response body is logged,
+// the bytes read for logging are put back in front of the body so it can still be read by the caller.
+func (b *Billwerk) logAttempt(req *http.Request, attempt int, duration time.Duration, res *http.Response, err error) {
+	if b.logger == nil {
+		return
+	}
+
+	ctx := req.Context()
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("endpoint", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case err != nil:
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", err.Error()))
+	case res.StatusCode >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+	if res != nil {
+		attrs = append(attrs,
+			slog.Int("status", res.StatusCode),
+			slog.String("request_id", requestID(res.Header)),
+		)
+	}
+
+	if b.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request_headers", b.redactHeader(req.Header)))
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				attrs = append(attrs, slog.String("request_body", b.redactBody(body)))
+			}
+		}
+		if res != nil {
+			data, _ := io.ReadAll(io.LimitReader(res.Body, maxRedactedBodyBytes+1))
+			res.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(data), res.Body), res.Body}
+			attrs = append(attrs, slog.String("response_body", b.redactBody(bytes.NewReader(data))))
+		}
+	}
+
+	b.logger.LogAttrs(ctx, level, "billwerk request", attrs...)
+}
+
+// redactHeader returns a copy of the header with the Authorization header
+// and all headers containing the API key redacted.
+func (b *Billwerk) redactHeader(header http.Header) http.Header {
+	clone := header.Clone()
+	for key, values := range clone {
+		for i, value := range values {
+			if strings.EqualFold(key, "Authorization") || (b.apiKey != "" && strings.Contains(value, b.apiKey)) {
+				values[i] = redacted
+			}
+		}
+	}
+
+	return clone
+}
+
+// redactBody reads a JSON body and returns it with secrets and redacted fields replaced.
+// Bodies that are not JSON or too large are omitted, as their content cannot be redacted reliably.
+func (b *Billwerk) redactBody(body io.Reader) string {
+	data, err := io.ReadAll(io.LimitReader(body, maxRedactedBodyBytes+1))
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	if len(data) > maxRedactedBodyBytes {
+		return "[omitted large body]"
+	}
+
+	var v interface{}
+	if err = json.Unmarshal(data, &v); err != nil {
+		return "[omitted non-JSON body]"
+	}
+
+	fields := b.redactedFields
+	if fields == nil {
+		fields = DefaultRedactedFields
+	}
+	redactValue(v, append(append([]string{}, secretFields...), fields...))
+
+	data, err = json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+
+	logged := string(data)
+	if b.apiKey != "" {
+		logged = strings.ReplaceAll(logged, b.apiKey, redacted)
+	}
+	if len(logged) > maxLoggedBodyBytes {
+		logged = logged[:maxLoggedBodyBytes] + "..."
+	}
+
+	return logged
+}
+
+// redactValue replaces the values of the given fields in a decoded JSON value.
+func redactValue(v interface{}, fields []string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if containsFold(fields, key) {
+				v[key] = redacted
+				continue
+			}
+			redactValue(value, fields)
+		}
+	case []interface{}:
+		for _, value := range v {
+			redactValue(value, fields)
+		}
+	}
+}
+
+// containsFold reports whether s is in values, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package optimize
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// logRecords decodes the records logged by a JSON handler.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func newDebugLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func TestLogRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "request-1")
+		_, _ = io.Copy(w, r.Body)
+	}, WithLogger(newDebugLogger(&buf)))
+
+	ctx := WithIdempotencyKey(context.Background(), "key-api-key")
+	customer := &Customer{
+		Handle:     "c-1",
+		Email:      "jane@example.com",
+		FirstName:  "Jane",
+		LastName:   "Doe",
+		Company:    "Example",
+		Address:    "Main Street 1",
+		City:       "Aarhus",
+		PostalCode: "8000",
+		Country:    "DK",
+		Vat:        "DK12345678",
+	}
+	res, err := b.CreateCustomer(ctx, customer)
+	if err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+	if res.Email != customer.Email {
+		t.Errorf("response email = %q, want the unredacted response", res.Email)
+	}
+
+	logged := buf.String()
+	for _, value := range []string{"jane@example.com", "Jane", "Doe", "Example", "Main Street", "Aarhus", "8000", "DK12345678", "api-key"} {
+		if strings.Contains(logged, value) {
+			t.Errorf("log contains %q: %s", value, logged)
+		}
+	}
+
+	record := logRecords(t, &buf)[0]
+	if record["level"] != "INFO" || record["request_id"] != "request-1" || record["status"] != float64(http.StatusOK) {
+		t.Errorf("log record = %v, want info with status and request id", record)
+	}
+	if !strings.Contains(record["request_body"].(string), `"handle":"c-1"`) || !strings.Contains(record["request_body"].(string), `"country":"DK"`) {
+		t.Errorf("request body = %v, want fields without personal data kept", record["request_body"])
+	}
+
+	headers := record["request_headers"].(map[string]interface{})
+	for _, header := range []string{"Authorization", idempotencyKeyHeader} {
+		if values := headers[header].([]interface{}); values[0] != redacted {
+			t.Errorf("header %s = %v, want redacted", header, values)
+		}
+	}
+}
+
+func TestLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}, WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+
+	_, _ = b.GetCustomer(context.Background(), "missing")
+
+	record := logRecords(t, &buf)[0]
+	if record["level"] != "WARN" {
+		t.Errorf("level = %v, want WARN", record["level"])
+	}
+	if _, ok := record["request_headers"]; ok {
+		t.Error("headers logged below debug level")
+	}
+}
+
+func TestLogLargeResponseBody(t *testing.T) {
+	large := `{"handle":"c-1","email":"` + strings.Repeat("x", maxRedactedBodyBytes) + `"}`
+
+	var buf bytes.Buffer
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(large))
+	}, WithLogger(newDebugLogger(&buf)))
+
+	res, err := b.GetCustomer(context.Background(), "c-1")
+	if err != nil {
+		t.Fatalf("GetCustomer() error = %v", err)
+	}
+	if len(res.Email) != maxRedactedBodyBytes {
+		t.Errorf("response email has %d bytes, want the whole body to be readable", len(res.Email))
+	}
+
+	if record := logRecords(t, &buf)[0]; record["response_body"] != "[omitted large body]" {
+		t.Errorf("response body = %.100v, want omitted", record["response_body"])
+	}
+}
@@ -0,0 +1,228 @@
+package otelbillwerk
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// versionSegment matches the API version prefix of a path, e.g. v1.
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// unknownOperation is the operation name of requests not matching a route.
+const unknownOperation = "unknown"
+
+// operation describes the API operation of a request.
+type operation struct {
+	// Resource the request operates on, e.g. plan.
+	resource string
+
+	// Name of the operation including the resource, e.g. plan.get.
+	name string
+
+	// Handle of the resource if the request targets a single resource.
+	handle string
+}
+
+// route maps a method and path pattern of the API to an operation name.
+// In patterns, {handle} matches the handle of the resource and {id} matches any other identifier,
+// e.g. a plan version or the handle of a sub resource, which is not recorded.
+type route struct {
+	method  string
+	pattern string
+	name    string
+}
+
+// routes are the routes of all API calls of the client.
+// Routes with a literal segment must be listed before routes with a placeholder at the same position.
+// Keep in sync with the client when adding API calls.
+var routes = []route{
+	{http.MethodGet, "/account/webhook_settings", "webhook_settings.get"},
+	{http.MethodPut, "/account/webhook_settings", "webhook_settings.update"},
+
+	{http.MethodGet, "/list/add_on", "add_on.list"},
+	{http.MethodPost, "/add_on", "add_on.create"},
+	{http.MethodGet, "/add_on/{handle}", "add_on.get"},
+	{http.MethodPut, "/add_on/{handle}", "add_on.update"},
+	{http.MethodPost, "/add_on/{handle}", "add_on.supersede"},
+	{http.MethodDelete, "/add_on/{handle}", "add_on.delete"},
+	{http.MethodPost, "/add_on/{handle}/undelete", "add_on.undelete"},
+	{http.MethodGet, "/add_on/{handle}/metadata", "add_on.metadata.get"},
+	{http.MethodPut, "/add_on/{handle}/metadata", "add_on.metadata.update"},
+	{http.MethodDelete, "/add_on/{handle}/metadata", "add_on.metadata.delete"},
+
+	{http.MethodPost, "/charge", "charge.create"},
+	{http.MethodGet, "/charge/{handle}", "charge.get"},
+	{http.MethodPost, "/charge/{handle}/settle", "charge.settle"},
+	{http.MethodPost, "/charge/{handle}/cancel", "charge.cancel"},
+	{http.MethodPost, "/charge/{handle}/prepare_recurring", "charge.prepare_recurring"},
+
+	{http.MethodGet, "/list/coupon", "coupon.list"},
+	{http.MethodGet, "/coupon/code/validate", "coupon.validate"},
+	{http.MethodPost, "/coupon", "coupon.create"},
+	{http.MethodGet, "/coupon/{handle}", "coupon.get"},
+	{http.MethodPut, "/coupon/{handle}", "coupon.update"},
+	{http.MethodDelete, "/coupon/{handle}", "coupon.delete"},
+	{http.MethodPost, "/coupon/{handle}/expire", "coupon.expire"},
+
+	{http.MethodGet, "/list/credit_note", "credit_note.list"},
+	{http.MethodGet, "/credit_note/{handle}", "credit_note.get"},
+
+	{http.MethodGet, "/list/customer", "customer.list"},
+	{http.MethodPost, "/customer", "customer.create"},
+	{http.MethodGet, "/customer/{handle}", "customer.get"},
+	{http.MethodPut, "/customer/{handle}", "customer.update"},
+	{http.MethodDelete, "/customer/{handle}", "customer.delete"},
+	{http.MethodPost, "/customer/{handle}/undelete", "customer.undelete"},
+	{http.MethodGet, "/customer/{handle}/metadata", "customer.metadata.get"},
+	{http.MethodPut, "/customer/{handle}/metadata", "customer.metadata.update"},
+	{http.MethodDelete, "/customer/{handle}/metadata", "customer.metadata.delete"},
+	{http.MethodGet, "/customer/{handle}/payment_method", "customer.payment_method.list"},
+
+	{http.MethodGet, "/list/discount", "discount.list"},
+	{http.MethodPost, "/discount", "discount.create"},
+	{http.MethodGet, "/discount/{handle}", "discount.get"},
+	{http.MethodPut, "/discount/{handle}", "discount.update"},
+	{http.MethodDelete, "/discount/{handle}", "discount.delete"},
+	{http.MethodPost, "/discount/{handle}/undelete", "discount.undelete"},
+
+	{http.MethodGet, "/dunning_plan", "dunning_plan.list"},
+	{http.MethodPost, "/dunning_plan", "dunning_plan.create"},
+	{http.MethodGet, "/dunning_plan/{handle}", "dunning_plan.get"},
+	{http.MethodPut, "/dunning_plan/{handle}", "dunning_plan.update"},
+	{http.MethodDelete, "/dunning_plan/{handle}", "dunning_plan.delete"},
+
+	{http.MethodGet, "/list/entitlement", "entitlement.list"},
+	{http.MethodPost, "/entitlement", "entitlement.create"},
+	{http.MethodGet, "/entitlement/{handle}", "entitlement.get"},
+	{http.MethodPut, "/entitlement/{handle}", "entitlement.update"},
+	{http.MethodDelete, "/entitlement/{handle}", "entitlement.delete"},
+
+	{http.MethodGet, "/list/event", "event.list"},
+	{http.MethodGet, "/event/{handle}", "event.get"},
+
+	{http.MethodGet, "/list/invoice", "invoice.list"},
+	{http.MethodGet, "/invoice/{handle}", "invoice.get"},
+	{http.MethodPost, "/invoice/{handle}/settle", "invoice.settle"},
+	{http.MethodPost, "/invoice/{handle}/cancel", "invoice.cancel"},
+	{http.MethodPost, "/invoice/{handle}/reactivate", "invoice.reactivate"},
+	{http.MethodPost, "/invoice/{handle}/manual_transaction", "invoice.manual_transaction"},
+	{http.MethodPost, "/invoice/{handle}/transaction/{id}/cancel", "invoice.transaction.cancel"},
+
+	{http.MethodGet, "/payment_method/{handle}", "payment_method.get"},
+	{http.MethodDelete, "/payment_method/{handle}", "payment_method.inactivate"},
+	{http.MethodPost, "/payment_method/{handle}/reactivate", "payment_method.reactivate"},
+
+	{http.MethodGet, "/list/plan", "plan.list"},
+	{http.MethodPost, "/plan", "plan.create"},
+	{http.MethodGet, "/plan/{handle}", "plan.version.list"},
+	{http.MethodPut, "/plan/{handle}", "plan.update"},
+	{http.MethodPost, "/plan/{handle}", "plan.supersede"},
+	{http.MethodDelete, "/plan/{handle}", "plan.delete"},
+	{http.MethodGet, "/plan/{handle}/current", "plan.get"},
+	{http.MethodPost, "/plan/{handle}/undelete", "plan.undelete"},
+	{http.MethodGet, "/plan/{handle}/metadata", "plan.metadata.get"},
+	{http.MethodPut, "/plan/{handle}/metadata", "plan.metadata.update"},
+	{http.MethodDelete, "/plan/{handle}/metadata", "plan.metadata.delete"},
+	{http.MethodGet, "/plan/{handle}/{id}/entitlement", "plan.entitlement.list"},
+	{http.MethodPost, "/plan/{handle}/{id}/entitlement", "plan.entitlement.add"},
+	{http.MethodDelete, "/plan/{handle}/{id}/entitlement/{id}", "plan.entitlement.remove"},
+
+	{http.MethodPost, "/refund", "refund.create"},
+	{http.MethodGet, "/refund/{handle}", "refund.get"},
+
+	{http.MethodPost, "/session/charge", "session.charge.create"},
+	{http.MethodPost, "/session/subscription", "session.subscription.create"},
+	{http.MethodPost, "/session/recurring", "session.recurring.create"},
+
+	{http.MethodGet, "/list/subscription", "subscription.list"},
+	{http.MethodPost, "/subscription", "subscription.create"},
+	{http.MethodGet, "/subscription/{handle}", "subscription.get"},
+	{http.MethodPut, "/subscription/{handle}", "subscription.change"},
+	{http.MethodPost, "/subscription/{handle}/cancel", "subscription.cancel"},
+	{http.MethodPost, "/subscription/{handle}/uncancel", "subscription.uncancel"},
+	{http.MethodPost, "/subscription/{handle}/on_hold", "subscription.on_hold"},
+	{http.MethodPost, "/subscription/{handle}/reactivate", "subscription.reactivate"},
+	{http.MethodPost, "/subscription/{handle}/expire", "subscription.expire"},
+	{http.MethodPost, "/subscription/{handle}/change_next_period_start", "subscription.change_next_period_start"},
+	{http.MethodPost, "/subscription/{handle}/coupon", "subscription.coupon.redeem"},
+	{http.MethodGet, "/subscription/{handle}/add_on", "subscription.add_on.list"},
+	{http.MethodPost, "/subscription/{handle}/add_on", "subscription.add_on.add"},
+	{http.MethodGet, "/subscription/{handle}/add_on/{id}", "subscription.add_on.get"},
+	{http.MethodPut, "/subscription/{handle}/add_on/{id}", "subscription.add_on.update"},
+	{http.MethodDelete, "/subscription/{handle}/add_on/{id}", "subscription.add_on.remove"},
+	{http.MethodGet, "/subscription/{handle}/discount", "subscription.discount.list"},
+	{http.MethodPost, "/subscription/{handle}/discount", "subscription.discount.add"},
+	{http.MethodDelete, "/subscription/{handle}/discount/{id}", "subscription.discount.delete"},
+
+	{http.MethodGet, "/tax_policy", "tax_policy.list"},
+	{http.MethodPost, "/tax_policy", "tax_policy.create"},
+	{http.MethodGet, "/tax_policy/{handle}", "tax_policy.get"},
+	{http.MethodPut, "/tax_policy/{handle}", "tax_policy.update"},
+	{http.MethodDelete, "/tax_policy/{handle}", "tax_policy.delete"},
+
+	{http.MethodGet, "/list/webhook", "webhook.list"},
+	{http.MethodPost, "/webhook/resend", "webhook.resend"},
+	{http.MethodGet, "/webhook/{handle}", "webhook.get"},
+	{http.MethodGet, "/webhook/{handle}/request", "webhook.request.list"},
+}
+
+// routeSegments are the path segments of the route patterns, in the order of routes.
+var routeSegments = func() [][]string {
+	segments := make([][]string, len(routes))
+	for i, r := range routes {
+		segments[i] = splitPath(r.pattern)
+	}
+	return segments
+}()
+
+// parseOperation returns the operation of the route matching the method and path of a request.
+// Requests not matching any route get the operation name unknown, so that paths never end up
+// in span names or metric attributes.
+func parseOperation(req *http.Request) operation {
+	segments := splitPath(req.URL.Path)
+	if len(segments) > 0 && versionSegment.MatchString(segments[0]) {
+		segments = segments[1:]
+	}
+
+	for i, r := range routes {
+		if r.method != req.Method {
+			continue
+		}
+		if handle, ok := match(routeSegments[i], segments); ok {
+			resource, _, _ := strings.Cut(r.name, ".")
+			return operation{resource: resource, name: r.name, handle: handle}
+		}
+	}
+
+	return operation{name: unknownOperation}
+}
+
+// match reports whether the path segments match the pattern segments and returns the matched handle.
+func match(pattern, segments []string) (string, bool) {
+	if len(pattern) != len(segments) {
+		return "", false
+	}
+
+	var handle string
+	for i, segment := range pattern {
+		switch segment {
+		case "{handle}":
+			handle = segments[i]
+		case "{id}":
+		default:
+			if segment != segments[i] {
+				return "", false
+			}
+		}
+	}
+
+	return handle, true
+}
+
+// splitPath splits a path into its non-empty segments.
+func splitPath(path string) []string {
+	return strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/'
+	})
+}
@@ -0,0 +1,174 @@
+// Package otelbillwerk instruments the Billwerk Optimize client with OpenTelemetry.
+//
+// Every call of Billwerk.Do is recorded as a client span named after the API operation, e.g. plan.get,
+// and counted in request count and latency metrics. The trace context is propagated to the API.
+//
+//	client := optimize.New(apiKey, otelbillwerk.WithTelemetry())
+//
+// The package is a module of its own, so only users of the instrumentation depend on OpenTelemetry:
+//
+//	go get github.com/moonliightz/go-billwerk/optimize/otelbillwerk
+package otelbillwerk
+
+import (
+	"errors"
+	"github.com/moonliightz/go-billwerk/optimize"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and meter.
+const ScopeName = "github.com/moonliightz/go-billwerk/optimize/otelbillwerk"
+
+// Attribute keys recorded on spans and metrics.
+const (
+	ResourceKey   = attribute.Key("billwerk.resource")   // Resource of the operation, e.g. plan.
+	OperationKey  = attribute.Key("billwerk.operation")  // Name of the operation, e.g. plan.get.
+	HandleKey     = attribute.Key("billwerk.handle")     // Handle of the resource. Not recorded on metrics.
+	RequestIDKey  = attribute.Key("billwerk.request_id") // Request id of the response. Not recorded on metrics.
+	ErrorCodeKey  = attribute.Key("billwerk.error.code") // Error code of the ErrorResponse.
+	StatusCodeKey = attribute.Key("http.response.status_code")
+	MethodKey     = attribute.Key("http.request.method")
+	ErrorTypeKey  = attribute.Key("error.type") // HTTP status code of a failed call or transport.
+)
+
+// config is the configuration of the instrumentation.
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option is a function that sets options for the instrumentation configuration.
+type Option func(config *config)
+
+// WithTracerProvider allows setting the tracer provider. Defaults to the global tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(config *config) {
+		config.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider allows setting the meter provider. Defaults to the global meter provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(config *config) {
+		config.meterProvider = provider
+	}
+}
+
+// WithPropagators allows setting the propagators used to inject the trace context into requests.
+// Defaults to the global propagators.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(config *config) {
+		config.propagators = propagators
+	}
+}
+
+// WithTelemetry returns a client option adding the instrumentation as interceptor.
+// Use it before other interceptors, so the span covers them as well.
+func WithTelemetry(opts ...Option) optimize.Option {
+	return optimize.WithInterceptors(NewInterceptor(opts...))
+}
+
+// NewInterceptor creates an interceptor recording a span and metrics for every call of Billwerk.Do.
+// The span covers all attempts of the call, including retries and waiting for the rate limit.
+func NewInterceptor(opts ...Option) optimize.Interceptor {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	tracer := c.tracerProvider.Tracer(ScopeName)
+	meter := c.meterProvider.Meter(ScopeName)
+
+	requests, err := meter.Int64Counter("billwerk.client.requests",
+		metric.WithDescription("Number of Billwerk API calls."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	duration, err := meter.Float64Histogram("billwerk.client.request.duration",
+		metric.WithDescription("Duration of Billwerk API calls including retries."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(req *http.Request, next optimize.RoundTripFunc) (*http.Response, error) {
+		op := parseOperation(req)
+		start := time.Now()
+
+		attrs := []attribute.KeyValue{
+			OperationKey.String(op.name),
+			MethodKey.String(req.Method),
+		}
+		if op.resource != "" {
+			attrs = append(attrs, ResourceKey.String(op.resource))
+		}
+
+		ctx, span := tracer.Start(req.Context(), op.name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+		if op.handle != "" {
+			span.SetAttributes(HandleKey.String(op.handle))
+		}
+
+		req = req.WithContext(ctx)
+		c.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		res, err := next(req)
+
+		var resAttrs []attribute.KeyValue
+		if res != nil {
+			resAttrs = append(resAttrs, StatusCodeKey.Int(res.StatusCode))
+			requestID := res.Header.Get("Request-Id")
+			if requestID == "" {
+				requestID = res.Header.Get("X-Request-Id")
+			}
+			if requestID != "" {
+				span.SetAttributes(RequestIDKey.String(requestID))
+			}
+		}
+
+		var errRes *optimize.ErrorResponse
+		switch {
+		case errors.As(err, &errRes):
+			resAttrs = append(resAttrs, ErrorTypeKey.String(strconv.Itoa(errRes.HTTPStatus)))
+			if errRes.Code != 0 {
+				resAttrs = append(resAttrs, ErrorCodeKey.Int(errRes.Code))
+			}
+			if errRes.RequestID != "" {
+				span.SetAttributes(RequestIDKey.String(errRes.RequestID))
+			}
+			span.SetStatus(codes.Error, errRes.Error())
+		case err != nil:
+			resAttrs = append(resAttrs, ErrorTypeKey.String("transport"))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.SetAttributes(resAttrs...)
+
+		attrs = append(attrs, resAttrs...)
+		requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+		duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+
+		return res, err
+	}
+}
@@ -0,0 +1,265 @@
+package otelbillwerk
+
+import (
+	"context"
+	"errors"
+	"github.com/moonliightz/go-billwerk/optimize"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testSetup is a Billwerk client instrumented with in-memory exporters.
+type testSetup struct {
+	billwerk     *optimize.Billwerk
+	spans        *tracetest.SpanRecorder
+	metrics      *sdkmetric.ManualReader
+	tracer       trace.Tracer
+	traceparents chan string
+}
+
+func newTestSetup(t *testing.T, handler http.HandlerFunc) *testSetup {
+	t.Helper()
+
+	traceparents := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents <- r.Header.Get("traceparent")
+		handler(w, r)
+	}))
+
+	baseURL := optimize.BaseURL
+	optimize.BaseURL = server.URL + "/v1"
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	metrics := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metrics))
+
+	s := &testSetup{
+		billwerk: optimize.New("api-key", WithTelemetry(
+			WithTracerProvider(tracerProvider),
+			WithMeterProvider(meterProvider),
+			WithPropagators(propagation.TraceContext{}),
+		)),
+		spans:        spans,
+		metrics:      metrics,
+		tracer:       tracerProvider.Tracer("test"),
+		traceparents: traceparents,
+	}
+
+	t.Cleanup(func() {
+		server.Close()
+		optimize.BaseURL = baseURL
+	})
+
+	return s
+}
+
+func TestSpan(t *testing.T) {
+	s := newTestSetup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "request-1")
+		_, _ = w.Write([]byte(`{"handle":"gold"}`))
+	})
+
+	if _, err := s.billwerk.GetPlan(context.Background(), "gold"); err != nil {
+		t.Fatalf("GetPlan() error = %v", err)
+	}
+
+	spans := s.spans.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "plan.get" {
+		t.Errorf("span name = %q, want %q", span.Name(), "plan.get")
+	}
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span kind = %v, want %v", span.SpanKind(), trace.SpanKindClient)
+	}
+	if span.Status().Code != codes.Unset {
+		t.Errorf("span status = %v, want %v", span.Status().Code, codes.Unset)
+	}
+
+	assertAttributes(t, span.Attributes(), map[attribute.Key]attribute.Value{
+		OperationKey:  attribute.StringValue("plan.get"),
+		ResourceKey:   attribute.StringValue("plan"),
+		HandleKey:     attribute.StringValue("gold"),
+		MethodKey:     attribute.StringValue(http.MethodGet),
+		StatusCodeKey: attribute.IntValue(http.StatusOK),
+		RequestIDKey:  attribute.StringValue("request-1"),
+	})
+}
+
+func TestSpanErrorResponse(t *testing.T) {
+	s := newTestSetup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":10,"error":"Customer not found","http_status":404,"request_id":"request-2"}`))
+	})
+
+	_, err := s.billwerk.GetCustomer(context.Background(), "missing")
+	if !errors.Is(err, optimize.ErrNotFound) {
+		t.Fatalf("GetCustomer() error = %v, want %v", err, optimize.ErrNotFound)
+	}
+
+	span := s.spans.Ended()[0]
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want %v", span.Status().Code, codes.Error)
+	}
+
+	assertAttributes(t, span.Attributes(), map[attribute.Key]attribute.Value{
+		OperationKey:  attribute.StringValue("customer.get"),
+		StatusCodeKey: attribute.IntValue(http.StatusNotFound),
+		ErrorCodeKey:  attribute.IntValue(10),
+		ErrorTypeKey:  attribute.StringValue("404"),
+		RequestIDKey:  attribute.StringValue("request-2"),
+	})
+}
+
+func TestContextPropagation(t *testing.T) {
+	s := newTestSetup(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	ctx, parent := s.tracer.Start(context.Background(), "parent")
+	_, err := s.billwerk.GetSubscription(ctx, "sub-1")
+	parent.End()
+	if err != nil {
+		t.Fatalf("GetSubscription() error = %v", err)
+	}
+
+	var span sdktrace.ReadOnlySpan
+	for _, ended := range s.spans.Ended() {
+		if ended.Name() == "subscription.get" {
+			span = ended
+		}
+	}
+	if span == nil {
+		t.Fatal("no subscription.get span recorded")
+	}
+
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("span parent = %v, want %v", span.Parent().SpanID(), parent.SpanContext().SpanID())
+	}
+
+	traceparent := <-s.traceparents
+	want := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"
+	if traceparent != want {
+		t.Errorf("traceparent = %q, want %q", traceparent, want)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s := newTestSetup(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/customer/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":10,"error":"Customer not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	ctx := context.Background()
+	_, _ = s.billwerk.GetCustomer(ctx, "c-1")
+	_, _ = s.billwerk.GetCustomer(ctx, "c-2")
+	_, _ = s.billwerk.GetCustomer(ctx, "missing")
+
+	var rm metricdata.ResourceMetrics
+	if err := s.metrics.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	requests, ok := metrics["billwerk.client.requests"].(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("billwerk.client.requests is %T, want metricdata.Sum[int64]", metrics["billwerk.client.requests"])
+	}
+
+	counts := make(map[int64]int64)
+	for _, dp := range requests.DataPoints {
+		if _, ok := dp.Attributes.Value(HandleKey); ok {
+			t.Errorf("data point has handle attribute: %v", dp.Attributes)
+		}
+		status, _ := dp.Attributes.Value(StatusCodeKey)
+		counts[status.AsInt64()] += dp.Value
+	}
+	if counts[http.StatusOK] != 2 || counts[http.StatusNotFound] != 1 {
+		t.Errorf("request counts by status = %v, want 2 x 200 and 1 x 404", counts)
+	}
+
+	duration, ok := metrics["billwerk.client.request.duration"].(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("billwerk.client.request.duration is %T, want metricdata.Histogram[float64]", metrics["billwerk.client.request.duration"])
+	}
+
+	var total uint64
+	for _, dp := range duration.DataPoints {
+		total += dp.Count
+		if code, ok := dp.Attributes.Value(ErrorCodeKey); ok && code.AsInt64() != 10 {
+			t.Errorf("error code = %d, want 10", code.AsInt64())
+		}
+	}
+	if total != 3 {
+		t.Errorf("recorded durations = %d, want 3", total)
+	}
+}
+
+func TestParseOperation(t *testing.T) {
+	tests := []struct {
+		method    string
+		path      string
+		operation operation
+	}{
+		{http.MethodGet, "/v1/plan/gold/current", operation{"plan", "plan.get", "gold"}},
+		{http.MethodPost, "/v1/plan/gold", operation{"plan", "plan.supersede", "gold"}},
+		{http.MethodGet, "/v1/plan/gold/3/entitlement", operation{"plan", "plan.entitlement.list", "gold"}},
+		{http.MethodDelete, "/v1/plan/gold/3/entitlement/api", operation{"plan", "plan.entitlement.remove", "gold"}},
+		{http.MethodGet, "/v1/list/subscription", operation{"subscription", "subscription.list", ""}},
+		{http.MethodPost, "/v1/subscription/sub-1/cancel", operation{"subscription", "subscription.cancel", "sub-1"}},
+		{http.MethodPost, "/v1/invoice/inv-1/transaction/tx-1/cancel", operation{"invoice", "invoice.transaction.cancel", "inv-1"}},
+		{http.MethodPost, "/v1/webhook/resend", operation{"webhook", "webhook.resend", ""}},
+		{http.MethodPost, "/v1/session/charge", operation{"session", "session.charge.create", ""}},
+		{http.MethodGet, "/v1/coupon/code/validate", operation{"coupon", "coupon.validate", ""}},
+		{http.MethodGet, "/v1/customer/c-1/payment_method", operation{"customer", "customer.payment_method.list", "c-1"}},
+		{http.MethodGet, "/v1/unknown/path/with/many/segments", operation{"", unknownOperation, ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if got := parseOperation(req); got != tt.operation {
+				t.Errorf("parseOperation() = %+v, want %+v", got, tt.operation)
+			}
+		})
+	}
+}
+
+// assertAttributes checks that attrs contains the wanted attributes.
+func assertAttributes(t *testing.T, attrs []attribute.KeyValue, want map[attribute.Key]attribute.Value) {
+	t.Helper()
+
+	got := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, attr := range attrs {
+		got[attr.Key] = attr.Value
+	}
+
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("attribute %s = %v, want %v", key, got[key].Emit(), value.Emit())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package optimize
+
+import (
+	"context"
+	"iter"
+)
+
+// ListResponse is implemented by the responses of all list endpoints with token based pagination.
+type ListResponse[T any] interface {
+	// Items returns the items of the page.
+	Items() []T
+
+	// NextPage returns the token for the next page, or an empty string for the last page.
+	NextPage() string
+}
+
+// ListFunc retrieves a page of a list endpoint, e.g. (*Billwerk).GetListOfPlans.
+type ListFunc[T any, R ListResponse[T]] func(ctx context.Context, params ...QueryParamFunc) (R, error)
+
+// Paginate returns an iterator over all items of a list endpoint.
+// The next page is requested with the next page token of the previous page until the last page is reached.
+// If size is greater than zero it is used as page size, otherwise the API default is used.
+//
+// The iteration stops after yielding an error, which happens if a page cannot be retrieved
+// or the context is cancelled.
+//
+// Example:
+//
+//	for plan, err := range Paginate(ctx, b.GetListOfPlans, 100, WithQueryParam(State, PlanStateActive)) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(plan.Handle)
+//	}
+func Paginate[T any, R ListResponse[T]](ctx context.Context, list ListFunc[T, R], size int, params ...QueryParamFunc) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		pageParams := params[:len(params):len(params)]
+		if size > 0 {
+			pageParams = append(pageParams, WithQueryParam(Size, size))
+		}
+		baseLen := len(pageParams)
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			page, err := list(ctx, pageParams...)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items := page.Items()
+			for _, item := range items {
+				if err = ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			nextPageToken := page.NextPage()
+			if nextPageToken == "" || len(items) == 0 {
+				return
+			}
+			pageParams = append(pageParams[:baseLen:baseLen], WithQueryParam(NextPageToken, nextPageToken))
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package optimize
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PaymentMethodState represents the state of a payment method.
+type PaymentMethodState string
+
+const (
+	PaymentMethodStateActive      PaymentMethodState = "active"      // Payment method can be used.
+	PaymentMethodStateInactivated PaymentMethodState = "inactivated" // Payment method has been inactivated.
+	PaymentMethodStateFailed      PaymentMethodState = "failed"      // Payment method has failed.
+	PaymentMethodStatePending     PaymentMethodState = "pending"     // Payment method is awaiting confirmation.
+	PaymentMethodStateDeleted     PaymentMethodState = "deleted"     // Payment method is deleted.
+)
+
+// PaymentMethodType represents the type of payment method and determines which details are present.
+type PaymentMethodType string
+
+const (
+	PaymentMethodTypeCard      PaymentMethodType = "card"                    // Card payment method.
+	PaymentMethodTypeSepa      PaymentMethodType = "sepa"                    // SEPA direct debit mandate.
+	PaymentMethodTypeMobilePay PaymentMethodType = "mobilepay_subscriptions" // MobilePay Subscriptions agreement.
+)
+
+// PaymentMethodDetails holds the type specific details of a payment method.
+// The concrete type is one of *CardDetails, *SepaMandateDetails or *MobilePayDetails.
+type PaymentMethodDetails interface {
+	// PaymentMethodType returns the payment method type the details belong to.
+	PaymentMethodType() PaymentMethodType
+}
+
+// CardDetails holds the details of a card payment method.
+type CardDetails struct {
+	// Card type, e.g. visa, mc or dankort.
+	CardType string `json:"card_type"`
+
+	// Transaction card type used for the last transaction.
+	TransactionCardType string `json:"transaction_card_type,omitempty"`
+
+	// Masked card number.
+	MaskedCard string `json:"masked_card"`
+
+	// Card expiry date on the form MM-YY.
+	ExpDate string `json:"exp_date"`
+
+	// Card fingerprint, unique per card number.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Card issuing country in ISO 3166-1 alpha-2.
+	CardCountry string `json:"card_country,omitempty"`
+
+	// Reference at the payment gateway.
+	GatewayReference string `json:"gw_ref,omitempty"`
+
+	// Error code of the last failed use of the card.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// PaymentMethodType returns PaymentMethodTypeCard.
+func (d *CardDetails) PaymentMethodType() PaymentMethodType {
+	return PaymentMethodTypeCard
+}
+
+// SepaMandateDetails holds the details of a SEPA direct debit mandate.
+type SepaMandateDetails struct {
+	// Mandate reference.
+	MandateReference string `json:"mandate_reference"`
+
+	// Masked IBAN of the debtor account.
+	MaskedIBAN string `json:"iban"`
+
+	// BIC of the debtor bank.
+	BIC string `json:"bic,omitempty"`
+
+	// Name of the account holder.
+	AccountHolder string `json:"account_holder,omitempty"`
+
+	// Date when the mandate was signed.
+	Signed *time.Time `json:"signed,omitempty"`
+}
+
+// PaymentMethodType returns PaymentMethodTypeSepa.
+func (d *SepaMandateDetails) PaymentMethodType() PaymentMethodType {
+	return PaymentMethodTypeSepa
+}
+
+// MobilePayDetails holds the details of a MobilePay Subscriptions agreement.
+type MobilePayDetails struct {
+	// Agreement id at MobilePay.
+	ExternalID string `json:"external_id"`
+
+	// Status of the agreement at MobilePay.
+	Status string `json:"status,omitempty"`
+
+	// Masked phone number of the MobilePay user.
+	PhoneNumber string `json:"phone_number,omitempty"`
+}
+
+// PaymentMethodType returns PaymentMethodTypeMobilePay.
+func (d *MobilePayDetails) PaymentMethodType() PaymentMethodType {
+	return PaymentMethodTypeMobilePay
+}
+
+// PaymentMethod defines the structure for a stored payment method of a customer.
+type PaymentMethod struct {
+	// Payment method id.
+	ID string `json:"id"`
+
+	// State of the payment method.
+	State PaymentMethodState `json:"state"`
+
+	// Customer handle.
+	Customer string `json:"customer"`
+
+	// Optional reference given when the payment method was added.
+	Reference string `json:"reference,omitempty"`
+
+	// Type of the payment method one of the following: card, sepa, mobilepay_subscriptions.
+	Type PaymentMethodType `json:"payment_type"`
+
+	// Type specific details. Nil if the type is not known to this client.
+	Details PaymentMethodDetails `json:"-"`
+
+	// Date when the payment method failed.
+	Failed *time.Time `json:"failed,omitempty"`
+
+	// Date when the payment method was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// paymentMethodJSON is the wire format of a payment method with one details object per type.
+type paymentMethodJSON struct {
+	ID        string              `json:"id"`
+	State     PaymentMethodState  `json:"state"`
+	Customer  string              `json:"customer"`
+	Reference string              `json:"reference,omitempty"`
+	Type      PaymentMethodType   `json:"payment_type"`
+	Failed    *time.Time          `json:"failed,omitempty"`
+	Created   *time.Time          `json:"created,omitempty"`
+	Card      *CardDetails        `json:"card,omitempty"`
+	Sepa      *SepaMandateDetails `json:"sepa,omitempty"`
+	MobilePay *MobilePayDetails   `json:"mps,omitempty"`
+}
+
+// UnmarshalJSON decodes a payment method and sets Details according to the payment type.
+func (p *PaymentMethod) UnmarshalJSON(data []byte) error {
+	var raw paymentMethodJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*p = PaymentMethod{
+		ID:        raw.ID,
+		State:     raw.State,
+		Customer:  raw.Customer,
+		Reference: raw.Reference,
+		Type:      raw.Type,
+		Failed:    raw.Failed,
+		Created:   raw.Created,
+	}
+
+	switch raw.Type {
+	case PaymentMethodTypeCard:
+		if raw.Card != nil {
+			p.Details = raw.Card
+		}
+	case PaymentMethodTypeSepa:
+		if raw.Sepa != nil {
+			p.Details = raw.Sepa
+		}
+	case PaymentMethodTypeMobilePay:
+		if raw.MobilePay != nil {
+			p.Details = raw.MobilePay
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes a payment method in the wire format with the details under the key of its type.
+func (p PaymentMethod) MarshalJSON() ([]byte, error) {
+	raw := paymentMethodJSON{
+		ID:        p.ID,
+		State:     p.State,
+		Customer:  p.Customer,
+		Reference: p.Reference,
+		Type:      p.Type,
+		Failed:    p.Failed,
+		Created:   p.Created,
+	}
+
+	switch details := p.Details.(type) {
+	case *CardDetails:
+		raw.Card = details
+	case *SepaMandateDetails:
+		raw.Sepa = details
+	case *MobilePayDetails:
+		raw.MobilePay = details
+	}
+
+	return json.Marshal(raw)
+}
+
+// Card returns the card details if the payment method is a card.
+func (p *PaymentMethod) Card() (*CardDetails, bool) {
+	details, ok := p.Details.(*CardDetails)
+	return details, ok
+}
+
+// SepaMandate returns the mandate details if the payment method is a SEPA mandate.
+func (p *PaymentMethod) SepaMandate() (*SepaMandateDetails, bool) {
+	details, ok := p.Details.(*SepaMandateDetails)
+	return details, ok
+}
+
+// MobilePay returns the agreement details if the payment method is a MobilePay Subscriptions agreement.
+func (p *PaymentMethod) MobilePay() (*MobilePayDetails, bool) {
+	details, ok := p.Details.(*MobilePayDetails)
+	return details, ok
+}
+
+// GetPaymentMethod retrieves a specific payment method by its id.
+func (b *Billwerk) GetPaymentMethod(ctx context.Context, id string) (*PaymentMethod, error) {
+	endpoint := fmt.Sprintf("/payment_method/%s", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res PaymentMethod
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetListOfCustomerPaymentMethods retrieves all payment methods of a customer by the customer handle.
+func (b *Billwerk) GetListOfCustomerPaymentMethods(ctx context.Context, customerHandle string) ([]*PaymentMethod, error) {
+	endpoint := fmt.Sprintf("/customer/%s/payment_method", customerHandle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*PaymentMethod
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// InactivatePaymentMethod inactivates a payment method by its id.
+// An inactivated payment method can no longer be used for payments.
+func (b *Billwerk) InactivatePaymentMethod(ctx context.Context, id string) (*PaymentMethod, error) {
+	endpoint := fmt.Sprintf("/payment_method/%s", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res PaymentMethod
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ReactivatePaymentMethod reactivates a failed or inactivated payment method by its id.
+func (b *Billwerk) ReactivatePaymentMethod(ctx context.Context, id string) (*PaymentMethod, error) {
+	endpoint := fmt.Sprintf("/payment_method/%s/reactivate", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res PaymentMethod
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
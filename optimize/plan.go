@@ -84,7 +84,8 @@ type Plan struct {
 	Description string `json:"description,omitempty"`
 
 	// Optional vat for this plan. Account default is used if none given.
-	Vat float64 `json:"vat,omitempty"`
+	// A pointer, so a zero rated plan can be told apart from a plan without vat.
+	Vat *float64 `json:"vat,omitempty"`
 
 	// Amount for the plan in the smallest unit for the account currency.
 	Amount int32 `json:"amount"`
@@ -260,6 +261,16 @@ type ListOfPlansResponse struct {
 	NextPageToken string    `json:"next_page_token"` // Token for the next page of results.
 }
 
+// Items returns the plans of the page.
+func (r *ListOfPlansResponse) Items() []*Plan {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of plans.
+func (r *ListOfPlansResponse) NextPage() string {
+	return r.NextPageToken
+}
+
 // PlanEntitlement defines entitlements associated with a plan.
 type PlanEntitlement struct {
 	Handle      string     `json:"handle"`      // Unique handle for the entitlement.
@@ -310,7 +321,6 @@ func (b *Billwerk) GetPlan(ctx context.Context, handle string, params ...QueryPa
 
 	var res Plan
 	if err = b.Do(req, &res); err != nil {
-		fmt.Printf("%+v\n", err)
 		return nil, err
 	}
 
@@ -464,6 +474,49 @@ func (b *Billwerk) GetPlanEntitlements(ctx context.Context, handle string, versi
 	return res, nil
 }
 
+// AddPlanEntitlements adds entitlements to a specific plan version by its handle and version
+// and returns all entitlements of the plan version.
+func (b *Billwerk) AddPlanEntitlements(ctx context.Context, handle string, version int32, entitlements *PlanEntitlementsAdd) ([]*PlanEntitlement, error) {
+	endpoint := fmt.Sprintf("/plan/%s/%d/entitlement", handle, version)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(entitlements)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*PlanEntitlement
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RemovePlanEntitlement removes an entitlement from a specific plan version by its handle and version
+// and returns the remaining entitlements of the plan version.
+func (b *Billwerk) RemovePlanEntitlement(ctx context.Context, handle string, version int32, entitlementHandle string) ([]*PlanEntitlement, error) {
+	endpoint := fmt.Sprintf("/plan/%s/%d/entitlement/%s", handle, version, entitlementHandle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*PlanEntitlement
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // GetPlanMetadata retrieves the metadata for a plan by its handle.
 // The result is stored in the metadata parameter and should be a pointer e.g. &map[string]interface{}{}
 // or &struct{}{} with the expected fields / json tags.
@@ -50,6 +50,18 @@ const (
 	FixedTrialDays            QueryParam = "fixed_trial_days"
 	Currency                  QueryParam = "currency"
 	TaxRateForCountry         QueryParam = "tax_rate_for_country"
+	Email                     QueryParam = "email"
+	FirstName                 QueryParam = "first_name"
+	LastName                  QueryParam = "last_name"
+	Company                   QueryParam = "company"
+	Country                   QueryParam = "country"
+	City                      QueryParam = "city"
+	PostalCode                QueryParam = "postal_code"
+	Phone                     QueryParam = "phone"
+	CustomerHandle            QueryParam = "customer"
+	PlanHandle                QueryParam = "plan"
+	Code                      QueryParam = "code"
+	Sort                      QueryParam = "sort"
 )
 
 // QueryParamFunc is a function that sets query parameters on the request builder.
@@ -0,0 +1,184 @@
+package optimize
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit defines a token bucket limiting the rate of requests sent by the client.
+type RateLimit struct {
+	// Number of requests allowed per second on average. A value of zero or less disables the limit.
+	RequestsPerSecond float64
+
+	// Maximum number of requests allowed in a burst. Defaults to 1.
+	Burst int
+}
+
+// WithRateLimit allows limiting the rate of requests sent by the Billwerk client.
+// Reads (GET, HEAD, OPTIONS) and writes (all other methods) are limited separately.
+//
+// The limit is applied to every attempt in Do and shared by all callers of the client.
+// If the API reports an exhausted rate limit, either with a 429 Too Many Requests and a
+// Retry-After header or with X-RateLimit-Remaining and X-RateLimit-Reset headers,
+// further requests are held back until the limit resets.
+func WithRateLimit(read, write RateLimit) Option {
+	return func(billwerk *Billwerk) {
+		billwerk.readLimiter = newTokenBucket(read)
+		billwerk.writeLimiter = newTokenBucket(write)
+	}
+}
+
+// tokenBucket is a concurrency safe token bucket. A nil bucket does not limit.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a token bucket for the rate limit or nil if the rate limit is disabled.
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns the time to wait before it may be used.
+// The token count may become negative, so concurrent callers queue up behind each other.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+	b.tokens--
+
+	var wait time.Duration
+	if b.last.After(now) {
+		// The bucket is paused until the rate limit of the API resets.
+		wait = b.last.Sub(now)
+	}
+	if b.tokens < 0 {
+		wait += time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+
+	return wait
+}
+
+// cancel returns a reserved token to the bucket, e.g. if the caller stopped waiting for it.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// pause holds back requests until the given time. Tokens are refilled from then on.
+func (b *tokenBucket) pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !until.After(b.last) {
+		return
+	}
+
+	b.last = until
+	if b.tokens > 1 {
+		b.tokens = 1
+	}
+}
+
+// wait blocks until a token is available or the context is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	delay := b.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// adapt pauses the bucket if the response reports an exhausted rate limit.
+func (b *tokenBucket) adapt(res *http.Response) {
+	if b == nil || res == nil {
+		return
+	}
+
+	now := time.Now()
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After"), now); ok {
+			b.pause(now.Add(wait))
+			return
+		}
+	}
+
+	if res.Header.Get("X-RateLimit-Remaining") != "0" {
+		return
+	}
+	if reset, ok := rateLimitReset(res.Header.Get("X-RateLimit-Reset"), now); ok {
+		b.pause(reset)
+	}
+}
+
+// rateLimitReset parses a X-RateLimit-Reset header value given either in seconds
+// until the reset or as a unix timestamp.
+func rateLimitReset(value string, now time.Time) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+
+	// Values larger than a year are unix timestamps.
+	if seconds > 365*24*60*60 {
+		return time.Unix(seconds, 0), true
+	}
+
+	return now.Add(time.Duration(seconds) * time.Second), true
+}
+
+// limiter returns the token bucket for the method of the request.
+func (b *Billwerk) limiter(req *http.Request) *tokenBucket {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return b.readLimiter
+	default:
+		return b.writeLimiter
+	}
+}
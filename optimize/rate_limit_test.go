@@ -0,0 +1,140 @@
+package optimize
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(RateLimit{RequestsPerSecond: 2, Burst: 2})
+	bucket.last = now
+
+	for i := range 2 {
+		if wait := bucket.reserve(now); wait != 0 {
+			t.Errorf("wait for request %d of burst = %v, want 0", i+1, wait)
+		}
+	}
+	if wait := bucket.reserve(now); wait != 500*time.Millisecond {
+		t.Errorf("wait after burst = %v, want 500ms", wait)
+	}
+	if wait := bucket.reserve(now); wait != time.Second {
+		t.Errorf("wait of queued request = %v, want 1s", wait)
+	}
+
+	// Tokens are refilled at the rate up to the burst.
+	if wait := bucket.reserve(now.Add(time.Minute)); wait != 0 {
+		t.Errorf("wait after refill = %v, want 0", wait)
+	}
+	if bucket.tokens != 1 {
+		t.Errorf("tokens = %v, want 1", bucket.tokens)
+	}
+}
+
+func TestTokenBucketDisabled(t *testing.T) {
+	if bucket := newTokenBucket(RateLimit{}); bucket != nil {
+		t.Errorf("newTokenBucket() = %+v, want nil", bucket)
+	}
+
+	var bucket *tokenBucket
+	if err := bucket.wait(context.Background()); err != nil {
+		t.Errorf("wait() of nil bucket error = %v", err)
+	}
+	bucket.adapt(&http.Response{StatusCode: http.StatusTooManyRequests})
+}
+
+func TestTokenBucketPause(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(RateLimit{RequestsPerSecond: 10, Burst: 5})
+	bucket.last = now
+
+	bucket.pause(now.Add(5 * time.Second))
+	if wait := bucket.reserve(now); wait != 5*time.Second {
+		t.Errorf("wait while paused = %v, want 5s", wait)
+	}
+	if wait := bucket.reserve(now); wait != 5*time.Second+100*time.Millisecond {
+		t.Errorf("wait of second request while paused = %v, want 5.1s", wait)
+	}
+
+	// An earlier reset does not shorten the pause.
+	bucket.pause(now.Add(time.Second))
+	if bucket.last != now.Add(5*time.Second) {
+		t.Errorf("paused until %v, want %v", bucket.last, now.Add(5*time.Second))
+	}
+}
+
+func TestTokenBucketAdapt(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		pause  time.Duration
+	}{
+		{"retry after", http.StatusTooManyRequests, http.Header{"Retry-After": []string{"3"}}, 3 * time.Second},
+		{"remaining zero", http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{"2"}}, 2 * time.Second},
+		{"reset as unix timestamp", http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{strconv.FormatInt(time.Now().Add(4*time.Second).Unix(), 10)}}, 4 * time.Second},
+		{"remaining requests", http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"5"}, "X-Ratelimit-Reset": []string{"2"}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := newTokenBucket(RateLimit{RequestsPerSecond: 100, Burst: 10})
+			start := time.Now()
+			bucket.adapt(&http.Response{StatusCode: tt.status, Header: tt.header})
+
+			paused := bucket.last.Sub(start)
+			if tt.pause == 0 && paused > 0 {
+				t.Errorf("paused for %v, want no pause", paused)
+			}
+			if tt.pause > 0 && (paused < tt.pause-time.Second || paused > tt.pause+time.Second) {
+				t.Errorf("paused for %v, want about %v", paused, tt.pause)
+			}
+		})
+	}
+}
+
+func TestTokenBucketWaitCancelled(t *testing.T) {
+	bucket := newTokenBucket(RateLimit{RequestsPerSecond: 1})
+	_ = bucket.wait(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := bucket.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait() error = %v, want %v", err, context.Canceled)
+	}
+	if bucket.tokens < -0.01 || bucket.tokens > 0.01 {
+		t.Errorf("tokens = %v, want the token of the cancelled wait returned", bucket.tokens)
+	}
+}
+
+func TestRateLimitReadsAndWrites(t *testing.T) {
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "60")
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}, WithRateLimit(RateLimit{RequestsPerSecond: 100, Burst: 10}, RateLimit{RequestsPerSecond: 100, Burst: 10}))
+
+	if _, err := b.GetCustomer(context.Background(), "c-1"); err != nil {
+		t.Fatalf("GetCustomer() error = %v", err)
+	}
+
+	// Writes are limited separately and not held back by the exhausted read limit.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := b.CreateCustomer(ctx, &Customer{Handle: "c-1"}); err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+
+	// Reads wait for the reset of the read limit.
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := b.GetCustomer(ctx, "c-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetCustomer() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
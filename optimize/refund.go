@@ -0,0 +1,147 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RefundState represents the state of a refund.
+type RefundState string
+
+const (
+	RefundStateRefunded   RefundState = "refunded"   // Refund succeeded.
+	RefundStateFailed     RefundState = "failed"     // Refund failed.
+	RefundStateProcessing RefundState = "processing" // Refund is being processed asynchronously.
+)
+
+// Refund defines the structure for a refund of a settled invoice or charge.
+type Refund struct {
+	// Refund id.
+	ID string `json:"id"`
+
+	// State of the refund.
+	State RefundState `json:"state"`
+
+	// Invoice id the refund belongs to.
+	Invoice string `json:"invoice"`
+
+	// Refunded amount.
+	Amount int32 `json:"amount"`
+
+	// Currency for the refund in ISO 4217 three letter alpha code.
+	Currency string `json:"currency"`
+
+	// Id of the refund transaction.
+	Transaction string `json:"transaction"`
+
+	// Id of the credit note issued for the refund.
+	CreditNoteID string `json:"credit_note_id,omitempty"`
+
+	// Id of the settle transaction that was refunded.
+	RefTransaction string `json:"ref_transaction,omitempty"`
+
+	// Error code if the refund failed.
+	Error string `json:"error,omitempty"`
+
+	// Error state if the refund failed: pending, soft_declined, hard_declined, processing_error.
+	ErrorState string `json:"error_state,omitempty"`
+
+	// Message from the acquirer if the refund failed.
+	AcquirerMessage string `json:"acquirer_message,omitempty"`
+
+	// Date when the refund was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// RefundNoteLine defines a credit note line sent when creating a refund.
+type RefundNoteLine struct {
+	// Per quantity amount to refund.
+	Amount int32 `json:"amount"`
+
+	// Credit note line text.
+	Text string `json:"text"`
+
+	// Quantity to refund.
+	Quantity int32 `json:"quantity"`
+
+	// Optional vat for the line as a fraction, e.g. 0.25. Invoice vat is used if none given.
+	Vat *float64 `json:"vat,omitempty"`
+
+	// Whether the amount is including vat. Default true.
+	AmountInclVat *bool `json:"amount_incl_vat,omitempty"`
+
+	// Optional id of the invoice order line being refunded.
+	OrderLineID string `json:"order_line_id,omitempty"`
+}
+
+// RefundCreate defines the request for creating a refund.
+// Either amount or note lines should be given, the full settled amount is refunded if none given.
+type RefundCreate struct {
+	// Id or handle of the invoice or charge to refund.
+	Invoice string `json:"invoice"`
+
+	// Optional key to identify the refund, the same key will not result in more than one refund.
+	Key string `json:"key,omitempty"`
+
+	// Optional amount to refund for a partial refund.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Optional credit note text used when refunding a plain amount.
+	Text string `json:"text,omitempty"`
+
+	// Optional vat for a plain amount refund.
+	Vat *float64 `json:"vat,omitempty"`
+
+	// Whether the plain amount is including vat. Default true.
+	AmountInclVat *bool `json:"amount_incl_vat,omitempty"`
+
+	// Optional note lines for an order line level refund.
+	NoteLines []*RefundNoteLine `json:"note_lines,omitempty"`
+
+	// Optional note for internal use.
+	Note string `json:"note,omitempty"`
+}
+
+// GetRefund retrieves a specific refund by its id.
+func (b *Billwerk) GetRefund(ctx context.Context, id string) (*Refund, error) {
+	endpoint := fmt.Sprintf("/refund/%s", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Refund
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateRefund refunds a settled invoice or charge fully or partially.
+// The resulting state of the refund is returned. If the refund is declined,
+// the reason is available in the TransactionError field of the returned ErrorResponse.
+func (b *Billwerk) CreateRefund(ctx context.Context, refund *RefundCreate) (*Refund, error) {
+	endpoint := "/refund"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(refund)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Refund
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
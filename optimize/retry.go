@@ -0,0 +1,205 @@
+package optimize
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// idempotencyKeyHeader is the header carrying the idempotency key of a request.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// maxRetryAfter is the longest Retry-After honored if the retry policy has no maximum backoff.
+const maxRetryAfter = time.Minute
+
+// RetryPolicy defines when and how often failed requests are retried.
+//
+// A request is retried if it failed with a network error, a 429 Too Many Requests or a 5xx status code,
+// and it is either idempotent (GET, HEAD, OPTIONS, PUT, DELETE) or carries an idempotency key.
+// Since Do sends all mutating requests with an idempotency key, POST requests are retried as well.
+type RetryPolicy struct {
+	// Maximum number of attempts including the first one. A value of 1 or less disables retries.
+	MaxAttempts int
+
+	// Backoff before the first retry. The backoff doubles for every further retry.
+	InitialBackoff time.Duration
+
+	// Maximum backoff between two attempts. Zero means no limit for the exponential backoff.
+	// Requests are not retried if a Retry-After header asks to wait longer than the maximum backoff,
+	// or longer than a minute if no maximum backoff is set.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is a retry policy suitable for most use cases.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+}
+
+// WithRetryPolicy allows setting a retry policy for the Billwerk client.
+// Requests are not retried unless a retry policy is set.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(billwerk *Billwerk) {
+		billwerk.retryPolicy = &policy
+	}
+}
+
+// maxAttempts returns the maximum number of attempts of the policy. A nil policy allows a single attempt.
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+// shouldRetry reports whether a request should be retried after it returned res and err.
+func (p *RetryPolicy) shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		if req.Header.Get(idempotencyKeyHeader) == "" {
+			return false
+		}
+	}
+
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff returns the time to wait before the next attempt after the given attempt.
+// A Retry-After header of the response takes precedence over the exponential backoff.
+// It reports false if the Retry-After header asks to wait longer than the maximum backoff,
+// in which case the request is not retried.
+func (p *RetryPolicy) backoff(attempt int, res *http.Response) (time.Duration, bool) {
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			limit := p.MaxBackoff
+			if limit <= 0 {
+				limit = maxRetryAfter
+			}
+			return wait, wait <= limit
+		}
+	}
+
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		if backoff <= 0 || backoff > math.MaxInt64/2 || (p.MaxBackoff > 0 && backoff >= p.MaxBackoff) {
+			break
+		}
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+
+	// Full jitter to spread retries of concurrent requests.
+	return rand.N(backoff + 1), true
+}
+
+// retryAfter parses a Retry-After header value given in seconds or as an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// errBodyNotReplayable is returned if a request body cannot be sent again for a retry.
+var errBodyNotReplayable = errors.New("request body is not replayable")
+
+// send executes the request and retries it according to the retry policy of the client.
+// Every attempt waits for the rate limit of the client. The response of the last attempt is returned.
+func (b *Billwerk) send(req *http.Request) (*http.Response, error) {
+	maxAttempts := b.retryPolicy.maxAttempts()
+	limiter := b.limiter(req)
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			var err error
+			if attemptReq, err = replay(req); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		res, err := b.httpClient.Do(attemptReq)
+		b.logAttempt(attemptReq, attempt, time.Since(start), res, err)
+		limiter.adapt(res)
+		if attempt >= maxAttempts || !b.retryPolicy.shouldRetry(req, res, err) {
+			return res, err
+		}
+
+		wait, ok := b.retryPolicy.backoff(attempt, res)
+		if !ok {
+			return res, err
+		}
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// replay returns a copy of the request with a fresh body for another attempt.
+func replay(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return clone, nil
+	}
+	if req.GetBody == nil {
+		return nil, errBodyNotReplayable
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone.Body = body
+
+	return clone, nil
+}
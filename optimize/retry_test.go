@@ -0,0 +1,167 @@
+package optimize
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		max     time.Duration
+	}{
+		{"first retry", RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Minute}, 1, time.Second},
+		{"doubles", RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Minute}, 3, 4 * time.Second},
+		{"capped", RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}, 10, 5 * time.Second},
+		{"doubles without cap", RetryPolicy{InitialBackoff: time.Second}, 6, 32 * time.Second},
+		{"no overflow", RetryPolicy{InitialBackoff: time.Second}, 100, time.Duration(math.MaxInt64)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var longest time.Duration
+			for range 200 {
+				wait, ok := tt.policy.backoff(tt.attempt, nil)
+				if !ok {
+					t.Fatal("backoff() = false, want true")
+				}
+				if wait < 0 || wait > tt.max {
+					t.Fatalf("backoff() = %v, want between 0 and %v", wait, tt.max)
+				}
+				longest = max(longest, wait)
+			}
+			if longest <= tt.max/2 {
+				t.Errorf("longest backoff = %v, want more than %v", longest, tt.max/2)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		retryAfter string
+		want       time.Duration
+		wantOK     bool
+	}{
+		{"seconds", RetryPolicy{MaxBackoff: 10 * time.Second}, "3", 3 * time.Second, true},
+		{"longer than max backoff", RetryPolicy{MaxBackoff: 10 * time.Second}, "30", 30 * time.Second, false},
+		{"no max backoff", RetryPolicy{}, "30", 30 * time.Second, true},
+		{"longer than a minute without max backoff", RetryPolicy{}, "3600", time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{"Retry-After": []string{tt.retryAfter}}}
+			wait, ok := tt.policy.backoff(1, res)
+			if wait != tt.want || ok != tt.wantOK {
+				t.Errorf("backoff() = %v, %v, want %v, %v", wait, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	wait, ok := retryAfter(now.Add(5*time.Second).UTC().Format(http.TimeFormat), now)
+	if !ok || wait <= 3*time.Second || wait > 5*time.Second {
+		t.Errorf("retryAfter() of HTTP date = %v, %v, want about 5s", wait, ok)
+	}
+	if _, ok = retryAfter("soon", now); ok {
+		t.Error("retryAfter() of invalid value = true, want false")
+	}
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		attempt := len(bodies)
+		mu.Unlock()
+
+		if attempt < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"handle":"c-1"}`))
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+	if _, err := b.CreateCustomer(context.Background(), &Customer{Handle: "c-1"}); err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+	if len(bodies) != 3 || bodies[0] == "" || bodies[1] != bodies[0] || bodies[2] != bodies[0] {
+		t.Errorf("request bodies = %q, want the same body for all attempts", bodies)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	attempts := 0
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+	_, err := b.GetCustomer(context.Background(), "c-1")
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("GetCustomer() error = %v, want %v", err, ErrServerError)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRetryLongRetryAfter(t *testing.T) {
+	attempts := 0
+	b := newTestBillwerk(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Second}))
+
+	_, err := b.GetCustomer(context.Background(), "c-1")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("GetCustomer() error = %v, want %v", err, ErrRateLimited)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestRetryTransportError(t *testing.T) {
+	attempts := 0
+	b := New("api-key",
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, errors.New("connection reset")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"handle":"c-1"}`)),
+			}, nil
+		})}),
+	)
+
+	customer, err := b.GetCustomer(context.Background(), "c-1")
+	if err != nil {
+		t.Fatalf("GetCustomer() error = %v", err)
+	}
+	if customer.Handle != "c-1" || attempts != 2 {
+		t.Errorf("GetCustomer() = %+v after %d attempts, want c-1 after 2", customer, attempts)
+	}
+}
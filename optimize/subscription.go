@@ -0,0 +1,582 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SubscriptionState represents the state of a subscription.
+type SubscriptionState string
+
+const (
+	SubscriptionStateActive  SubscriptionState = "active"  // Subscription is active.
+	SubscriptionStateExpired SubscriptionState = "expired" // Subscription has expired.
+	SubscriptionStateOnHold  SubscriptionState = "on_hold" // Subscription is on hold.
+	SubscriptionStatePending SubscriptionState = "pending" // Subscription is pending a payment method.
+)
+
+// SubscriptionChangeTiming defines when a subscription change takes effect.
+type SubscriptionChangeTiming string
+
+const (
+	SubscriptionChangeTimingImmediate SubscriptionChangeTiming = "immediate" // Change is applied immediately.
+	SubscriptionChangeTimingRenewal   SubscriptionChangeTiming = "renewal"   // Change is applied at next renewal.
+)
+
+// SubscriptionCompensationMethod defines how to compensate for a partial billing period.
+type SubscriptionCompensationMethod string
+
+const (
+	SubscriptionCompensationMethodNone           SubscriptionCompensationMethod = "none"            // No compensation.
+	SubscriptionCompensationMethodFullRefund     SubscriptionCompensationMethod = "full_refund"     // Refund the full period.
+	SubscriptionCompensationMethodProratedRefund SubscriptionCompensationMethod = "prorated_refund" // Refund the remaining part of the period.
+	SubscriptionCompensationMethodFullCredit     SubscriptionCompensationMethod = "full_credit"     // Credit the full period.
+	SubscriptionCompensationMethodProratedCredit SubscriptionCompensationMethod = "prorated_credit" // Credit the remaining part of the period.
+)
+
+// SubscriptionSignupMethod defines how the payment method for a new subscription is obtained.
+type SubscriptionSignupMethod string
+
+const (
+	SubscriptionSignupMethodSource SubscriptionSignupMethod = "source" // Payment method given by source.
+	SubscriptionSignupMethodEmail  SubscriptionSignupMethod = "email"  // Send a signup email to the customer.
+	SubscriptionSignupMethodLink   SubscriptionSignupMethod = "link"   // Return a hosted page link for signup.
+	SubscriptionSignupMethodNone   SubscriptionSignupMethod = "none"   // No payment method is obtained.
+)
+
+// SubscriptionRange represents the range for retrieving subscriptions.
+type SubscriptionRange string
+
+const (
+	SubscriptionRangeCreated SubscriptionRange = "created" // Retrieve subscriptions by creation date.
+)
+
+// Subscription defines the structure for a subscription.
+type Subscription struct {
+	// Per account unique handle for the subscription.
+	Handle string `json:"handle"`
+
+	// Customer handle.
+	Customer string `json:"customer"`
+
+	// Plan handle.
+	Plan string `json:"plan"`
+
+	// State of the subscription one of the following: active, expired, on_hold, pending.
+	State SubscriptionState `json:"state"`
+
+	// Whether the subscription is a test subscription.
+	Test bool `json:"test"`
+
+	// Optional subscription amount overriding the plan amount.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Subscription plan quantity.
+	Quantity int32 `json:"quantity,omitempty"`
+
+	// Date and time the subscription will expire if cancelled or with fixed life time.
+	Expires *time.Time `json:"expires,omitempty"`
+
+	// Date and time of the last reactivation.
+	Reactivated *time.Time `json:"reactivated,omitempty"`
+
+	// Subscription timezone.
+	Timezone string `json:"timezone,omitempty"`
+
+	// Date when the subscription was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Date when the subscription was activated.
+	Activated *time.Time `json:"activated,omitempty"`
+
+	// Whether the subscription is renewing.
+	Renewing bool `json:"renewing"`
+
+	// Version of the plan the subscription is on.
+	PlanVersion int32 `json:"plan_version,omitempty"`
+
+	// Whether the optional amount is including VAT.
+	AmountInclVat bool `json:"amount_incl_vat,omitempty"`
+
+	// Start date of the subscription.
+	StartDate *time.Time `json:"start_date,omitempty"`
+
+	// Fixed end date of the subscription.
+	EndDate *time.Time `json:"end_date,omitempty"`
+
+	// Grace duration in seconds before an unpaid subscription is put on hold.
+	GraceDuration int32 `json:"grace_duration,omitempty"`
+
+	// Start of the current billing period.
+	CurrentPeriodStart *time.Time `json:"current_period_start,omitempty"`
+
+	// Start of the next billing period.
+	NextPeriodStart *time.Time `json:"next_period_start,omitempty"`
+
+	// Start of the first billing period.
+	FirstPeriodStart *time.Time `json:"first_period_start,omitempty"`
+
+	// Start of the last billing period.
+	LastPeriodStart *time.Time `json:"last_period_start,omitempty"`
+
+	// Start of the trial period.
+	TrialStart *time.Time `json:"trial_start,omitempty"`
+
+	// End of the trial period.
+	TrialEnd *time.Time `json:"trial_end,omitempty"`
+
+	// Whether the subscription is cancelled.
+	IsCancelled bool `json:"is_cancelled"`
+
+	// Whether the subscription is in trial.
+	InTrial bool `json:"in_trial"`
+
+	// Whether the subscription has started.
+	HasStarted bool `json:"has_started"`
+
+	// Number of renewals.
+	RenewalCount int32 `json:"renewal_count"`
+
+	// Date the subscription was cancelled.
+	CancelledDate *time.Time `json:"cancelled_date,omitempty"`
+
+	// Date the subscription expired.
+	ExpiredDate *time.Time `json:"expired_date,omitempty"`
+
+	// Reason for expiry: dunning, cancelled, ondemand, fixed.
+	ExpireReason string `json:"expire_reason,omitempty"`
+
+	// Date the subscription was put on hold.
+	OnHoldDate *time.Time `json:"on_hold_date,omitempty"`
+
+	// Reason for on hold: dunning, ondemand.
+	OnHoldReason string `json:"on_hold_reason,omitempty"`
+
+	// Whether a payment method has been added to the subscription.
+	PaymentMethodAdded bool `json:"payment_method_added"`
+
+	// Plan handle of a plan change scheduled for next renewal.
+	ScheduledPlanChange string `json:"scheduled_plan_change,omitempty"`
+
+	// Whether a renewal reminder email has been sent for the current period.
+	ReminderEmailSent bool `json:"reminder_email_sent"`
+
+	// Number of failed invoices.
+	FailedInvoices int32 `json:"failed_invoices"`
+
+	// Total failed amount.
+	FailedAmount int64 `json:"failed_amount"`
+
+	// Number of cancelled invoices.
+	CancelledInvoices int32 `json:"cancelled_invoices"`
+
+	// Total cancelled amount.
+	CancelledAmount int64 `json:"cancelled_amount"`
+
+	// Number of pending invoices.
+	PendingInvoices int32 `json:"pending_invoices"`
+
+	// Total pending amount.
+	PendingAmount int64 `json:"pending_amount"`
+
+	// Number of invoices in dunning.
+	DunningInvoices int32 `json:"dunning_invoices"`
+
+	// Total dunning amount.
+	DunningAmount int64 `json:"dunning_amount"`
+
+	// Number of settled invoices.
+	SettledInvoices int32 `json:"settled_invoices"`
+
+	// Total settled amount.
+	SettledAmount int64 `json:"settled_amount"`
+
+	// Total refunded amount.
+	RefundedAmount int64 `json:"refunded_amount"`
+
+	// Hosted page links for the subscription, e.g. payment_info.
+	HostedPageLinks map[string]string `json:"hosted_page_links,omitempty"`
+
+	// Handles of the payment methods attached to the subscription.
+	PaymentMethods []string `json:"payment_methods,omitempty"`
+}
+
+// SubscriptionCreate defines the request for creating a subscription.
+type SubscriptionCreate struct {
+	// Customer handle of an existing customer. Either customer or create_customer must be given.
+	Customer string `json:"customer,omitempty"`
+
+	// Create a customer in conjunction with the subscription.
+	CreateCustomer *Customer `json:"create_customer,omitempty"`
+
+	// Plan handle.
+	Plan string `json:"plan"`
+
+	// Optional plan version. The current version is used if none given.
+	PlanVersion int32 `json:"plan_version,omitempty"`
+
+	// Per account unique handle for the subscription. Must be provided if generate_handle is not defined.
+	Handle string `json:"handle,omitempty"`
+
+	// Auto generate handle on the form sub-[sequence_number].
+	GenerateHandle bool `json:"generate_handle,omitempty"`
+
+	// Optional amount overriding the plan amount.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Whether the optional amount is including VAT. Default true.
+	AmountInclVat *bool `json:"amount_incl_vat,omitempty"`
+
+	// Optional quantity of the plan product. Default is 1.
+	Quantity int32 `json:"quantity,omitempty"`
+
+	// Whether the subscription is a test subscription.
+	Test bool `json:"test,omitempty"`
+
+	// Custom metadata.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// Payment method source, e.g. a card token or an existing payment method id.
+	Source string `json:"source,omitempty"`
+
+	// How the payment method is obtained: source, email, link, none.
+	SignupMethod SubscriptionSignupMethod `json:"signup_method"`
+
+	// Optional start date of the subscription. Default is now.
+	StartDate string `json:"start_date,omitempty"`
+
+	// Optional fixed end date of the subscription.
+	EndDate string `json:"end_date,omitempty"`
+
+	// Optional grace duration in seconds.
+	GraceDuration int32 `json:"grace_duration,omitempty"`
+
+	// Skip the trial period defined on the plan.
+	NoTrial bool `json:"no_trial,omitempty"`
+
+	// Skip the setup fee defined on the plan.
+	NoSetupFee bool `json:"no_setup_fee,omitempty"`
+
+	// Optional trial period overriding the plan trial, e.g. 7d or 1m.
+	TrialPeriod string `json:"trial_period,omitempty"`
+
+	// Optional coupon codes to redeem for the subscription.
+	CouponCodes []string `json:"coupon_codes,omitempty"`
+}
+
+// SubscriptionChange defines the request for changing a subscription.
+type SubscriptionChange struct {
+	// When the change takes effect: immediate or renewal.
+	Timing SubscriptionChangeTiming `json:"timing"`
+
+	// Optional new plan handle.
+	Plan string `json:"plan,omitempty"`
+
+	// Optional new amount overriding the plan amount.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Optional new quantity.
+	Quantity int32 `json:"quantity,omitempty"`
+
+	// Whether the amount is including VAT.
+	AmountInclVat *bool `json:"amount_incl_vat,omitempty"`
+
+	// Bill for the change immediately instead of at next renewal. Only for immediate timing.
+	Billing string `json:"billing,omitempty"`
+
+	// Compensation for the remaining part of the current period. Only for immediate timing.
+	CompensationMethod SubscriptionCompensationMethod `json:"compensation_method,omitempty"`
+
+	// Cancel a scheduled change instead of scheduling a new one.
+	CancelChange bool `json:"cancel_change,omitempty"`
+}
+
+// SubscriptionOnHold defines the request for putting a subscription on hold.
+type SubscriptionOnHold struct {
+	// Compensation for the remaining part of the current period.
+	CompensationMethod SubscriptionCompensationMethod `json:"compensation_method,omitempty"`
+}
+
+// SubscriptionReactivate defines the request for reactivating an on hold subscription.
+type SubscriptionReactivate struct {
+	// Optional date and time of the reactivation. Default is now.
+	Reactivate string `json:"reactivate,omitempty"`
+
+	// How to handle a potential partial billing period for fixed day scheduling.
+	PartialPeriodHandling PlanPartialPeriodHandling `json:"partial_period_handling,omitempty"`
+}
+
+// SubscriptionCancel defines the request for cancelling a subscription.
+type SubscriptionCancel struct {
+	// Optional number of notice periods overriding the plan setting.
+	NoticePeriods *int32 `json:"notice_periods,omitempty"`
+
+	// Whether the notice periods start at the end of the current period.
+	NoticePeriodsAfterCurrent *bool `json:"notice_periods_after_current,omitempty"`
+
+	// Optional fixed expire date overriding the notice periods.
+	ExpireAt string `json:"expire_at,omitempty"`
+}
+
+// SubscriptionExpire defines the request for expiring a subscription.
+type SubscriptionExpire struct {
+	// Compensation for the remaining part of the current period.
+	CompensationMethod SubscriptionCompensationMethod `json:"compensation_method,omitempty"`
+}
+
+// SubscriptionNextPeriodStart defines the request for changing the next period start of a subscription.
+type SubscriptionNextPeriodStart struct {
+	// New start of the next billing period. In ISO-8601 extended offset date-time format.
+	NextPeriodStart string `json:"next_period_start"`
+}
+
+// ListOfSubscriptionsResponse contains the response for listing subscriptions.
+type ListOfSubscriptionsResponse struct {
+	Size          int               `json:"size"`            // Number of subscriptions returned.
+	Count         int               `json:"count"`           // Total count of subscriptions.
+	To            string            `json:"to"`              // End of the range.
+	From          string            `json:"from"`            // Start of the range.
+	Content       []*Subscription   `json:"content"`         // List of subscriptions.
+	Range         SubscriptionRange `json:"range"`           // Subscription range.
+	NextPageToken string            `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the subscriptions of the page.
+func (r *ListOfSubscriptionsResponse) Items() []*Subscription {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of subscriptions.
+func (r *ListOfSubscriptionsResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// GetListOfSubscriptions retrieves a list of subscriptions based on the provided query parameters.
+func (b *Billwerk) GetListOfSubscriptions(ctx context.Context, params ...QueryParamFunc) (*ListOfSubscriptionsResponse, error) {
+	endpoint := "/list/subscription"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfSubscriptionsResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetSubscription retrieves a specific subscription by its handle.
+func (b *Billwerk) GetSubscription(ctx context.Context, handle string) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/subscription/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Subscription
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateSubscription creates a new subscription.
+func (b *Billwerk) CreateSubscription(ctx context.Context, subscription *SubscriptionCreate) (*Subscription, error) {
+	endpoint := "/subscription"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(subscription)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Subscription
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ChangeSubscription changes the plan, amount or quantity of a subscription by its handle.
+// The change is applied immediately or at next renewal depending on the timing.
+func (b *Billwerk) ChangeSubscription(ctx context.Context, handle string, change *SubscriptionChange) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/subscription/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(change)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Subscription
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// PutSubscriptionOnHold puts a subscription on hold by its handle. The onHold parameter is optional.
+func (b *Billwerk) PutSubscriptionOnHold(ctx context.Context, handle string, onHold *SubscriptionOnHold) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/on_hold", handle)
+
+	if onHold == nil {
+		onHold = &SubscriptionOnHold{}
+	}
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(onHold)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Subscription
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ReactivateSubscription reactivates an on hold subscription by its handle. The reactivate parameter is optional.
+func (b *Billwerk) ReactivateSubscription(ctx context.Context, handle string, reactivate *SubscriptionReactivate) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/reactivate", handle)
+
+	if reactivate == nil {
+		reactivate = &SubscriptionReactivate{}
+	}
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(reactivate)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Subscription
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CancelSubscription cancels a subscription by its handle. The cancel parameter is optional.
+// The subscription expires at the end of the notice periods.
+func (b *Billwerk) CancelSubscription(ctx context.Context, handle string, cancel *SubscriptionCancel) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/cancel", handle)
+
+	if cancel == nil {
+		cancel = &SubscriptionCancel{}
+	}
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(cancel)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Subscription
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UncancelSubscription reverts the cancellation of a subscription by its handle.
+func (b *Billwerk) UncancelSubscription(ctx context.Context, handle string) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/uncancel", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Subscription
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ExpireSubscription expires a subscription immediately by its handle. The expire parameter is optional.
+func (b *Billwerk) ExpireSubscription(ctx context.Context, handle string, expire *SubscriptionExpire) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/expire", handle)
+
+	if expire == nil {
+		expire = &SubscriptionExpire{}
+	}
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(expire)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Subscription
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SetSubscriptionNextPeriodStart changes the start of the next billing period of a subscription by its handle.
+func (b *Billwerk) SetSubscriptionNextPeriodStart(ctx context.Context, handle string, nextPeriodStart *SubscriptionNextPeriodStart) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/change_next_period_start", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(nextPeriodStart)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Subscription
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,174 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SubscriptionAddOn defines an add-on attached to a subscription.
+type SubscriptionAddOn struct {
+	// Per subscription unique handle for the subscription add-on.
+	Handle string `json:"handle"`
+
+	// Quantity of the add-on. Always 1 for on_off add-ons.
+	Quantity int32 `json:"quantity"`
+
+	// Whether the amount overrides the add-on amount with a fixed amount.
+	FixedAmount bool `json:"fixed_amount"`
+
+	// Optional amount overriding the add-on amount.
+	Amount int32 `json:"amount,omitempty"`
+
+	// Whether the optional amount is including VAT.
+	AmountInclVat bool `json:"amount_incl_vat,omitempty"`
+
+	// The add-on attached to the subscription.
+	AddOn *AddOn `json:"add_on"`
+
+	// Date when the subscription add-on was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// SubscriptionAddOnCreate defines the request for attaching an add-on to a subscription.
+type SubscriptionAddOnCreate struct {
+	// Handle of the add-on to attach.
+	AddOn string `json:"add_on"`
+
+	// Optional per subscription unique handle for the subscription add-on.
+	// The add-on handle is used if none given.
+	Handle string `json:"handle,omitempty"`
+
+	// Optional quantity for quantity add-ons. Default is 1.
+	Quantity int32 `json:"quantity,omitempty"`
+
+	// Optional amount overriding the add-on amount.
+	Amount *int32 `json:"amount,omitempty"`
+
+	// Whether the optional amount is including VAT. Default true.
+	AmountInclVat *bool `json:"amount_incl_vat,omitempty"`
+
+	// Whether the optional amount is a fixed amount not multiplied by quantity.
+	FixedAmount bool `json:"fixed_amount,omitempty"`
+}
+
+// SubscriptionAddOnUpdate defines the request for updating an add-on attached to a subscription.
+// Fields left nil are not changed.
+type SubscriptionAddOnUpdate struct {
+	// New quantity for quantity add-ons.
+	Quantity *int32 `json:"quantity,omitempty"`
+
+	// New amount overriding the add-on amount.
+	Amount *int32 `json:"amount,omitempty"`
+
+	// Whether the amount is including VAT.
+	AmountInclVat *bool `json:"amount_incl_vat,omitempty"`
+
+	// Whether the amount is a fixed amount not multiplied by quantity.
+	FixedAmount *bool `json:"fixed_amount,omitempty"`
+}
+
+// GetListOfSubscriptionAddOns retrieves all add-ons attached to a subscription by its handle.
+func (b *Billwerk) GetListOfSubscriptionAddOns(ctx context.Context, handle string) ([]*SubscriptionAddOn, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/add_on", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*SubscriptionAddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetSubscriptionAddOn retrieves a specific add-on attached to a subscription
+// by the subscription handle and the subscription add-on handle.
+func (b *Billwerk) GetSubscriptionAddOn(ctx context.Context, handle string, addOnHandle string) (*SubscriptionAddOn, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/add_on/%s", handle, addOnHandle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res SubscriptionAddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// AddSubscriptionAddOn attaches an add-on to a subscription by its handle.
+func (b *Billwerk) AddSubscriptionAddOn(ctx context.Context, handle string, addOn *SubscriptionAddOnCreate) (*SubscriptionAddOn, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/add_on", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(addOn)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res SubscriptionAddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateSubscriptionAddOn updates the quantity or amount override of an add-on attached to a subscription
+// by the subscription handle and the subscription add-on handle.
+func (b *Billwerk) UpdateSubscriptionAddOn(ctx context.Context, handle string, addOnHandle string, addOn *SubscriptionAddOnUpdate) (*SubscriptionAddOn, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/add_on/%s", handle, addOnHandle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(addOn)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res SubscriptionAddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RemoveSubscriptionAddOn removes an add-on from a subscription
+// by the subscription handle and the subscription add-on handle.
+func (b *Billwerk) RemoveSubscriptionAddOn(ctx context.Context, handle string, addOnHandle string) (*SubscriptionAddOn, error) {
+	endpoint := fmt.Sprintf("/subscription/%s/add_on/%s", handle, addOnHandle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res SubscriptionAddOn
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,182 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaxRate defines the tax rate of a tax policy for a country.
+type TaxRate struct {
+	// Country in ISO 3166-1 alpha-2 the rate applies to.
+	Country string `json:"country"`
+
+	// Tax rate as a fraction, e.g. 0.25.
+	Rate float64 `json:"rate"`
+}
+
+// TaxPolicy defines the structure for a tax policy.
+type TaxPolicy struct {
+	// Per account unique handle for the tax policy. Max length 255 with allowable characters [a-zA-Z0-9_.-@].
+	Handle string `json:"handle"`
+
+	// Name of the tax policy.
+	Name string `json:"name"`
+
+	// Tax rates per country.
+	TaxRates []*TaxRate `json:"tax_rates"`
+
+	// Date when the tax policy was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+
+	// Date when the tax policy was deleted. In ISO-8601 extended offset date-time format.
+	Deleted *time.Time `json:"deleted,omitempty"`
+}
+
+// RateForCountry returns the tax rate of the policy for a country in ISO 3166-1 alpha-2
+// and whether the policy defines a rate for the country.
+func (t *TaxPolicy) RateForCountry(country string) (float64, bool) {
+	for _, taxRate := range t.TaxRates {
+		if strings.EqualFold(taxRate.Country, country) {
+			return taxRate.Rate, true
+		}
+	}
+
+	return 0, false
+}
+
+// ResolveTaxRate returns the effective tax rate of a plan for a customer country.
+//
+// The rate of the tax policy for the country takes precedence over the vat of the plan,
+// which takes precedence over the account default vat. The account default vat is only used if the plan
+// has no vat, a plan vat of zero means the plan is zero rated. The policy may be nil if the plan has no tax policy.
+func ResolveTaxRate(plan *Plan, policy *TaxPolicy, country string, accountDefaultVat float64) float64 {
+	if plan.TaxPolicy != "" && policy != nil {
+		if rate, ok := policy.RateForCountry(country); ok {
+			return rate
+		}
+	}
+
+	if plan.Vat != nil {
+		return *plan.Vat
+	}
+
+	return accountDefaultVat
+}
+
+// GetListOfTaxPolicies retrieves all tax policies.
+func (b *Billwerk) GetListOfTaxPolicies(ctx context.Context) ([]*TaxPolicy, error) {
+	endpoint := "/tax_policy"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*TaxPolicy
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetTaxPolicy retrieves a specific tax policy by its handle.
+func (b *Billwerk) GetTaxPolicy(ctx context.Context, handle string) (*TaxPolicy, error) {
+	endpoint := fmt.Sprintf("/tax_policy/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res TaxPolicy
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// CreateTaxPolicy creates a new tax policy.
+func (b *Billwerk) CreateTaxPolicy(ctx context.Context, taxPolicy *TaxPolicy) (*TaxPolicy, error) {
+	endpoint := "/tax_policy"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(taxPolicy)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res TaxPolicy
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateTaxPolicy updates an existing tax policy by its handle.
+func (b *Billwerk) UpdateTaxPolicy(ctx context.Context, handle string, taxPolicy *TaxPolicy) (*TaxPolicy, error) {
+	endpoint := fmt.Sprintf("/tax_policy/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(taxPolicy)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res TaxPolicy
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DeleteTaxPolicy deletes a tax policy by its handle.
+func (b *Billwerk) DeleteTaxPolicy(ctx context.Context, handle string) (*TaxPolicy, error) {
+	endpoint := fmt.Sprintf("/tax_policy/%s", handle)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.DELETE()
+	if err != nil {
+		return nil, err
+	}
+
+	var res TaxPolicy
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetPlanTaxRate resolves the effective tax rate of a plan for a customer country
+// as described for ResolveTaxRate. The tax policy of the plan is retrieved if the plan has one.
+func (b *Billwerk) GetPlanTaxRate(ctx context.Context, plan *Plan, country string, accountDefaultVat float64) (float64, error) {
+	var policy *TaxPolicy
+	if plan.TaxPolicy != "" {
+		var err error
+		if policy, err = b.GetTaxPolicy(ctx, plan.TaxPolicy); err != nil {
+			return 0, err
+		}
+	}
+
+	return ResolveTaxRate(plan, policy, country, accountDefaultVat), nil
+}
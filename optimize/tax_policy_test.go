@@ -0,0 +1,31 @@
+package optimize
+
+import (
+	"testing"
+)
+
+func TestResolveTaxRate(t *testing.T) {
+	zero, planVat := 0.0, 0.19
+	policy := &TaxPolicy{TaxRates: []*TaxRate{{Country: "DK", Rate: 0.25}}}
+
+	tests := []struct {
+		name    string
+		plan    *Plan
+		country string
+		want    float64
+	}{
+		{"tax policy", &Plan{TaxPolicy: "eu", Vat: &planVat}, "dk", 0.25},
+		{"country without policy rate", &Plan{TaxPolicy: "eu", Vat: &planVat}, "DE", 0.19},
+		{"plan vat", &Plan{Vat: &planVat}, "DK", 0.19},
+		{"zero rated plan", &Plan{Vat: &zero}, "DK", 0},
+		{"account default", &Plan{}, "DK", 0.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveTaxRate(tt.plan, policy, tt.country, 0.2); got != tt.want {
+				t.Errorf("ResolveTaxRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WebhookState represents the delivery state of a webhook.
+type WebhookState string
+
+const (
+	WebhookStatePending   WebhookState = "pending"   // Webhook is awaiting delivery.
+	WebhookStateRetrying  WebhookState = "retrying"  // Delivery failed and is being retried.
+	WebhookStateDisabled  WebhookState = "disabled"  // Webhooks are disabled for the account.
+	WebhookStateFailed    WebhookState = "failed"    // Delivery failed and retries are exhausted.
+	WebhookStateCompleted WebhookState = "completed" // Webhook has been delivered.
+)
+
+// WebhookRange represents the range for retrieving webhooks.
+type WebhookRange string
+
+const (
+	WebhookRangeCreated WebhookRange = "created" // Retrieve webhooks by creation date.
+)
+
+// WebhookSettings defines the webhook settings of the account.
+type WebhookSettings struct {
+	// URLs webhooks are delivered to.
+	URLs []string `json:"urls"`
+
+	// Optional username for basic authentication on delivery.
+	Username string `json:"username,omitempty"`
+
+	// Optional password for basic authentication on delivery.
+	Password string `json:"password,omitempty"`
+
+	// Whether webhook delivery is disabled.
+	Disabled bool `json:"disabled"`
+
+	// Email addresses alerted when deliveries keep failing.
+	AlertEmails []string `json:"alert_emails,omitempty"`
+
+	// Number of failed attempts before an alert is sent.
+	AlertCount int32 `json:"alert_count,omitempty"`
+
+	// Secret used to sign webhooks. Read only.
+	Secret string `json:"secret,omitempty"`
+}
+
+// Webhook defines a webhook delivery for an event.
+type Webhook struct {
+	// Webhook id.
+	ID string `json:"id"`
+
+	// Id of the event the webhook was triggered by.
+	Event string `json:"event"`
+
+	// Delivery state of the webhook.
+	State WebhookState `json:"state"`
+
+	// URL the webhook is delivered to.
+	URL string `json:"url"`
+
+	// Optional username used for basic authentication.
+	Username string `json:"username,omitempty"`
+
+	// JSON content of the webhook.
+	Content string `json:"content"`
+
+	// Number of delivery attempts.
+	Count int32 `json:"count"`
+
+	// Date of the successful delivery.
+	Success *time.Time `json:"success,omitempty"`
+
+	// Date of the first failed delivery attempt.
+	FirstFail *time.Time `json:"first_fail,omitempty"`
+
+	// Date of the last failed delivery attempt.
+	LastFail *time.Time `json:"last_fail,omitempty"`
+
+	// Number of alerts sent for the webhook.
+	AlertCount int32 `json:"alert_count,omitempty"`
+
+	// Date when the last alert was sent.
+	AlertSent *time.Time `json:"alert_sent,omitempty"`
+
+	// Date when the webhook was created. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// WebhookRequest defines a single delivery attempt of a webhook.
+type WebhookRequest struct {
+	// Request id.
+	ID string `json:"id"`
+
+	// Webhook id.
+	Webhook string `json:"webhook"`
+
+	// URL the request was sent to.
+	URL string `json:"url"`
+
+	// HTTP status code of the response, zero if no response was received.
+	StatusCode int `json:"status_code,omitempty"`
+
+	// Error if the request failed, e.g. a timeout or a connection error.
+	Error string `json:"error,omitempty"`
+
+	// Date when the request was sent. In ISO-8601 extended offset date-time format.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// WebhookResend defines the request for resending webhooks.
+type WebhookResend struct {
+	// Ids of the webhooks to resend.
+	Webhooks []string `json:"webhooks"`
+}
+
+// ListOfWebhooksResponse contains the response for listing webhooks.
+type ListOfWebhooksResponse struct {
+	Size          int          `json:"size"`            // Number of webhooks returned.
+	Count         int          `json:"count"`           // Total count of webhooks.
+	To            string       `json:"to"`              // End of the range.
+	From          string       `json:"from"`            // Start of the range.
+	Content       []*Webhook   `json:"content"`         // List of webhooks.
+	Range         WebhookRange `json:"range"`           // Webhook range.
+	NextPageToken string       `json:"next_page_token"` // Token for the next page of results.
+}
+
+// Items returns the webhooks of the page.
+func (r *ListOfWebhooksResponse) Items() []*Webhook {
+	return r.Content
+}
+
+// NextPage returns the token for the next page of webhooks.
+func (r *ListOfWebhooksResponse) NextPage() string {
+	return r.NextPageToken
+}
+
+// GetWebhookSettings retrieves the webhook settings of the account.
+func (b *Billwerk) GetWebhookSettings(ctx context.Context) (*WebhookSettings, error) {
+	endpoint := "/account/webhook_settings"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res WebhookSettings
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// UpdateWebhookSettings updates the webhook settings of the account.
+func (b *Billwerk) UpdateWebhookSettings(ctx context.Context, settings *WebhookSettings) (*WebhookSettings, error) {
+	endpoint := "/account/webhook_settings"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(settings)
+
+	req, err := requestBuilder.PUT()
+	if err != nil {
+		return nil, err
+	}
+
+	var res WebhookSettings
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetListOfWebhooks retrieves a list of webhooks based on the provided query parameters.
+// Use WithQueryParam(State, WebhookStateFailed) to only list failed deliveries.
+func (b *Billwerk) GetListOfWebhooks(ctx context.Context, params ...QueryParamFunc) (*ListOfWebhooksResponse, error) {
+	endpoint := "/list/webhook"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	for _, param := range params {
+		param(requestBuilder)
+	}
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res ListOfWebhooksResponse
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetWebhook retrieves a specific webhook by its id.
+func (b *Billwerk) GetWebhook(ctx context.Context, id string) (*Webhook, error) {
+	endpoint := fmt.Sprintf("/webhook/%s", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res Webhook
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetWebhookRequests retrieves all delivery attempts of a webhook by its id.
+func (b *Billwerk) GetWebhookRequests(ctx context.Context, id string) ([]*WebhookRequest, error) {
+	endpoint := fmt.Sprintf("/webhook/%s/request", id)
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint)
+
+	req, err := requestBuilder.GET()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*WebhookRequest
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ResendWebhooks requests a new delivery of the given webhooks.
+func (b *Billwerk) ResendWebhooks(ctx context.Context, resend *WebhookResend) ([]*Webhook, error) {
+	endpoint := "/webhook/resend"
+
+	requestBuilder := b.newBillwerkRequest(ctx).
+		WithEndpoint(endpoint).
+		WithJSONBody(resend)
+
+	req, err := requestBuilder.POST()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*Webhook
+	if err = b.Do(req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/moonliightz/go-billwerk/optimize"
+	"time"
+)
+
+var (
+	// ErrInvalidSignature is returned if the signature of a webhook does not match its content.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+	// ErrStaleEvent is returned if the timestamp of a webhook is outside the allowed tolerance.
+	ErrStaleEvent = errors.New("webhook: stale event")
+
+	// ErrEmptySecret is returned if no webhook secret is given, as anyone could sign webhooks with it.
+	ErrEmptySecret = errors.New("webhook: empty secret")
+)
+
+// Event defines the payload of a webhook.
+// Only the fields relevant for the event type are set.
+type Event struct {
+	// Unique id of the webhook. Is the same for all delivery attempts.
+	ID string `json:"id"`
+
+	// Id of the event that triggered the webhook.
+	EventID string `json:"event_id"`
+
+	// Type of the event.
+	EventType optimize.EventType `json:"event_type"`
+
+	// Date when the webhook was triggered.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Hex encoded HMAC-SHA256 signature of the timestamp and id.
+	Signature string `json:"signature"`
+
+	// Customer handle.
+	Customer string `json:"customer,omitempty"`
+
+	// Payment method id for payment method events.
+	PaymentMethod string `json:"payment_method,omitempty"`
+
+	// Optional reference given when the payment method was added.
+	PaymentMethodReference string `json:"payment_method_reference,omitempty"`
+
+	// Subscription handle for subscription events and subscription invoices.
+	Subscription string `json:"subscription,omitempty"`
+
+	// Invoice handle for invoice events.
+	Invoice string `json:"invoice,omitempty"`
+
+	// Transaction id for invoice events involving a transaction.
+	Transaction string `json:"transaction,omitempty"`
+
+	// Credit note id for invoice events involving a credit note.
+	CreditNote string `json:"credit_note,omitempty"`
+
+	// Credit id for invoice events involving a credit.
+	Credit string `json:"credit,omitempty"`
+}
+
+// eventJSON is the wire format of a webhook. The timestamp is kept as sent
+// since the signature is calculated over the raw value.
+type eventJSON struct {
+	Event
+	Timestamp string `json:"timestamp"`
+}
+
+// Sign calculates the hex encoded HMAC-SHA256 signature of a webhook
+// with the timestamp and id as sent and the webhook secret of the account.
+func Sign(secret, timestamp, id string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is the valid signature of a webhook
+// with the given timestamp and id. It always reports false for an empty secret.
+func VerifySignature(secret, timestamp, id, signature string) bool {
+	if secret == "" {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + id))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// ParseEvent decodes a webhook body and verifies its signature with the webhook secret.
+//
+// If tolerance is greater than zero, the webhook is rejected with ErrStaleEvent if its timestamp
+// differs more than tolerance from now. Failed deliveries and resent webhooks keep their original
+// timestamp, so a tolerance rejects all later delivery attempts. Use the webhook id to detect replays instead.
+func ParseEvent(body []byte, secret string, tolerance time.Duration, now time.Time) (*Event, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+
+	var raw eventJSON
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("webhook: failed to decode body: %w", err)
+	}
+
+	if !VerifySignature(secret, raw.Timestamp, raw.ID, raw.Signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, raw.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: failed to parse timestamp: %w", err)
+	}
+
+	if tolerance > 0 {
+		if age := now.Sub(timestamp); age > tolerance || age < -tolerance {
+			return nil, ErrStaleEvent
+		}
+	}
+
+	event := raw.Event
+	event.Timestamp = timestamp
+
+	return &event, nil
+}
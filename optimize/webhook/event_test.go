@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/moonliightz/go-billwerk/optimize"
+	"testing"
+	"time"
+)
+
+const testSecret = "webhook-secret"
+
+// signedBody returns the body of a webhook signed with the secret.
+func signedBody(t *testing.T, secret, id string, eventType optimize.EventType, timestamp time.Time) []byte {
+	t.Helper()
+
+	ts := timestamp.Format(time.RFC3339Nano)
+	body, err := json.Marshal(map[string]string{
+		"id":         id,
+		"event_id":   "event-" + id,
+		"event_type": string(eventType),
+		"timestamp":  ts,
+		"signature":  Sign(secret, ts, id),
+		"customer":   "c-1",
+	})
+	if err != nil {
+		t.Fatalf("failed to encode webhook: %v", err)
+	}
+
+	return body
+}
+
+func TestVerifySignature(t *testing.T) {
+	signature := Sign(testSecret, "2024-01-01T12:00:00.000Z", "wh-1")
+
+	tests := []struct {
+		name      string
+		secret    string
+		id        string
+		signature string
+		want      bool
+	}{
+		{"valid", testSecret, "wh-1", signature, true},
+		{"other id", testSecret, "wh-2", signature, false},
+		{"other secret", "other", "wh-1", signature, false},
+		{"empty secret", "", "wh-1", Sign("", "2024-01-01T12:00:00.000Z", "wh-1"), false},
+		{"not hex", testSecret, "wh-1", "not-hex", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifySignature(tt.secret, "2024-01-01T12:00:00.000Z", tt.id, tt.signature); got != tt.want {
+				t.Errorf("VerifySignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	body := signedBody(t, testSecret, "wh-1", optimize.EventTypeInvoiceSettled, timestamp)
+
+	event, err := ParseEvent(body, testSecret, 0, timestamp.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("ParseEvent() error = %v", err)
+	}
+	if event.ID != "wh-1" || event.EventType != optimize.EventTypeInvoiceSettled || event.Customer != "c-1" || !event.Timestamp.Equal(timestamp) {
+		t.Errorf("ParseEvent() = %+v", event)
+	}
+
+	if _, err = ParseEvent(body, "other", 0, timestamp); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("ParseEvent() with other secret error = %v, want %v", err, ErrInvalidSignature)
+	}
+	if _, err = ParseEvent(body, "", 0, timestamp); !errors.Is(err, ErrEmptySecret) {
+		t.Errorf("ParseEvent() with empty secret error = %v, want %v", err, ErrEmptySecret)
+	}
+	if _, err = ParseEvent(body, testSecret, time.Hour, timestamp.Add(2*time.Hour)); !errors.Is(err, ErrStaleEvent) {
+		t.Errorf("ParseEvent() with tolerance error = %v, want %v", err, ErrStaleEvent)
+	}
+	if _, err = ParseEvent([]byte("{"), testSecret, 0, timestamp); err == nil {
+		t.Error("ParseEvent() of malformed body succeeded")
+	}
+}
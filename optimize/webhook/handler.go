@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"github.com/moonliightz/go-billwerk/optimize"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultReplayWindow is the default time the ids of handled webhooks are remembered.
+	// It covers the period in which failed deliveries are retried.
+	DefaultReplayWindow = 72 * time.Hour
+
+	// maxBodyBytes is the maximum size of a webhook body.
+	maxBodyBytes = 1 << 20
+)
+
+// HandlerFunc is a callback for a verified webhook event.
+// Returning an error responds with a server error so the webhook is delivered again.
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// Handler is an http.Handler that receives webhooks, verifies their signature
+// and dispatches the decoded events to the registered callbacks.
+type Handler struct {
+	secret       string
+	tolerance    time.Duration
+	replayWindow time.Duration
+	now          func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[optimize.EventType][]HandlerFunc
+	fallback []HandlerFunc
+
+	handledMu sync.Mutex
+	handled   map[string]time.Time
+	pending   map[string]struct{}
+	lastPrune time.Time
+}
+
+// reservation is the result of reserving the id of a webhook for dispatch.
+type reservation int
+
+const (
+	reserved   reservation = iota // The webhook may be dispatched.
+	handled                       // The webhook was handled within the replay window.
+	inProgress                    // The webhook is being dispatched by a concurrent delivery.
+)
+
+// Option is a function that sets options for the webhook handler configuration.
+type Option func(handler *Handler)
+
+// WithTolerance allows setting the maximum age of a webhook timestamp. The check is disabled by default.
+// Failed deliveries are retried with the original timestamp, so the tolerance must cover
+// the whole retry period, otherwise retried webhooks are rejected and lost.
+func WithTolerance(tolerance time.Duration) Option {
+	return func(handler *Handler) {
+		handler.tolerance = tolerance
+	}
+}
+
+// WithReplayWindow allows setting the time the ids of handled webhooks are remembered.
+// Webhooks with an id handled within the window are acknowledged without calling the callbacks again.
+// A window of zero disables the replay protection.
+func WithReplayWindow(window time.Duration) Option {
+	return func(handler *Handler) {
+		handler.replayWindow = window
+	}
+}
+
+// WithClock allows setting the function used to get the current time.
+func WithClock(now func() time.Time) Option {
+	return func(handler *Handler) {
+		handler.now = now
+	}
+}
+
+// NewHandler creates a new webhook handler with the webhook secret of the account
+// and optional configuration options. It returns ErrEmptySecret if the secret is empty.
+func NewHandler(secret string, opts ...Option) (*Handler, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+
+	h := &Handler{
+		secret:       secret,
+		replayWindow: DefaultReplayWindow,
+		now:          time.Now,
+		handlers:     make(map[optimize.EventType][]HandlerFunc),
+		handled:      make(map[string]time.Time),
+		pending:      make(map[string]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h, nil
+}
+
+// On registers a callback for events of the given type.
+// Callbacks are called in the order they were registered.
+func (h *Handler) On(eventType optimize.EventType, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[eventType] = append(h.handlers[eventType], fn)
+}
+
+// OnAny registers a callback for events without a callback registered for their type.
+func (h *Handler) OnAny(fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fallback = append(h.fallback, fn)
+}
+
+// ServeHTTP verifies and decodes the webhook and calls the registered callbacks.
+//
+// It responds with 405 for methods other than POST, 400 for malformed or stale webhooks,
+// 401 for invalid signatures, 500 if a callback fails and 200 otherwise.
+// Webhooks already handled within the replay window are acknowledged with 200.
+// Webhooks being handled by a concurrent delivery are rejected with 409, so they are delivered
+// again if the concurrent delivery fails.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	event, err := ParseEvent(body, h.secret, h.tolerance, h.now())
+	if err != nil {
+		if errors.Is(err, ErrInvalidSignature) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	switch h.reserve(event.ID) {
+	case handled:
+		w.WriteHeader(http.StatusOK)
+		return
+	case inProgress:
+		http.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict)
+		return
+	}
+
+	if err = h.dispatchReserved(r.Context(), event); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// reserve claims the id of a webhook for dispatch, unless it was handled within the replay window
+// or is being dispatched. A reserved id must be released after the dispatch.
+func (h *Handler) reserve(id string) reservation {
+	if h.replayWindow <= 0 {
+		return reserved
+	}
+
+	h.handledMu.Lock()
+	defer h.handledMu.Unlock()
+
+	if _, ok := h.pending[id]; ok {
+		return inProgress
+	}
+	if handledAt, ok := h.handled[id]; ok && h.now().Sub(handledAt) < h.replayWindow {
+		return handled
+	}
+
+	h.pending[id] = struct{}{}
+	return reserved
+}
+
+// release releases a reserved id and remembers it if the webhook was handled.
+// Ids outside the replay window are forgotten at most once per replay window.
+func (h *Handler) release(id string, ok bool) {
+	if h.replayWindow <= 0 {
+		return
+	}
+
+	h.handledMu.Lock()
+	defer h.handledMu.Unlock()
+
+	delete(h.pending, id)
+	if !ok {
+		return
+	}
+
+	now := h.now()
+	if now.Sub(h.lastPrune) >= h.replayWindow {
+		for handledID, handledAt := range h.handled {
+			if now.Sub(handledAt) >= h.replayWindow {
+				delete(h.handled, handledID)
+			}
+		}
+		h.lastPrune = now
+	}
+	h.handled[id] = now
+}
+
+// dispatchReserved dispatches a webhook with a reserved id and releases the id afterwards,
+// also if a callback panics.
+func (h *Handler) dispatchReserved(ctx context.Context, event *Event) error {
+	ok := false
+	defer func() {
+		h.release(event.ID, ok)
+	}()
+
+	err := h.dispatch(ctx, event)
+	ok = err == nil
+
+	return err
+}
+
+// dispatch calls the callbacks registered for the event type, or the fallback callbacks if there are none.
+// It stops at the first callback returning an error.
+func (h *Handler) dispatch(ctx context.Context, event *Event) error {
+	h.mu.RLock()
+	handlers, ok := h.handlers[event.EventType]
+	if !ok {
+		handlers = h.fallback
+	}
+	h.mu.RUnlock()
+
+	for _, fn := range handlers {
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
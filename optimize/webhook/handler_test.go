@@ -0,0 +1,187 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/moonliightz/go-billwerk/optimize"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// deliver sends a webhook body to the handler and returns the response status.
+func deliver(h http.Handler, body []byte) int {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body)))
+
+	return rec.Code
+}
+
+func newTestHandler(t *testing.T, opts ...Option) *Handler {
+	t.Helper()
+
+	h, err := NewHandler(testSecret, opts...)
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	return h
+}
+
+func TestNewHandlerEmptySecret(t *testing.T) {
+	if _, err := NewHandler(""); !errors.Is(err, ErrEmptySecret) {
+		t.Errorf("NewHandler() error = %v, want %v", err, ErrEmptySecret)
+	}
+}
+
+func TestHandlerDispatch(t *testing.T) {
+	h := newTestHandler(t)
+
+	var settled, other []string
+	h.On(optimize.EventTypeInvoiceSettled, func(_ context.Context, event *Event) error {
+		settled = append(settled, event.ID)
+		return nil
+	})
+	h.OnAny(func(_ context.Context, event *Event) error {
+		other = append(other, event.ID)
+		return nil
+	})
+
+	now := time.Now()
+	if status := deliver(h, signedBody(t, testSecret, "wh-1", optimize.EventTypeInvoiceSettled, now)); status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	if status := deliver(h, signedBody(t, testSecret, "wh-2", optimize.EventTypeCustomerCreated, now)); status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+
+	if len(settled) != 1 || settled[0] != "wh-1" || len(other) != 1 || other[0] != "wh-2" {
+		t.Errorf("dispatched settled = %v and other = %v", settled, other)
+	}
+}
+
+func TestHandlerRejects(t *testing.T) {
+	h := newTestHandler(t)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status of GET = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+
+	if status := deliver(h, signedBody(t, "other", "wh-1", optimize.EventTypeInvoiceSettled, time.Now())); status != http.StatusUnauthorized {
+		t.Errorf("status of invalid signature = %d, want %d", status, http.StatusUnauthorized)
+	}
+	if status := deliver(h, []byte("{")); status != http.StatusBadRequest {
+		t.Errorf("status of malformed body = %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestHandlerRedelivery(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	h := newTestHandler(t, WithClock(func() time.Time { return now }))
+
+	calls := 0
+	h.OnAny(func(context.Context, *Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("callback failed")
+		}
+		return nil
+	})
+
+	body := signedBody(t, testSecret, "wh-1", optimize.EventTypeInvoiceSettled, now)
+	if status := deliver(h, body); status != http.StatusInternalServerError {
+		t.Errorf("status of failed delivery = %d, want %d", status, http.StatusInternalServerError)
+	}
+
+	// Retries keep the original timestamp and must be accepted hours later.
+	now = now.Add(6 * time.Hour)
+	if status := deliver(h, body); status != http.StatusOK {
+		t.Errorf("status of retry = %d, want %d", status, http.StatusOK)
+	}
+	if status := deliver(h, body); status != http.StatusOK {
+		t.Errorf("status of replay = %d, want %d", status, http.StatusOK)
+	}
+	if calls != 2 {
+		t.Errorf("callback called %d times, want 2", calls)
+	}
+
+	// Ids are forgotten after the replay window.
+	now = now.Add(DefaultReplayWindow)
+	deliver(h, signedBody(t, testSecret, "wh-2", optimize.EventTypeInvoiceSettled, now))
+	h.handledMu.Lock()
+	_, ok := h.handled["wh-1"]
+	h.handledMu.Unlock()
+	if ok {
+		t.Error("id outside the replay window not forgotten")
+	}
+}
+
+func TestHandlerConcurrentDelivery(t *testing.T) {
+	h := newTestHandler(t)
+
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	h.OnAny(func(context.Context, *Event) error {
+		calls.Add(1)
+		close(started)
+		<-release
+		return nil
+	})
+
+	body := signedBody(t, testSecret, "wh-1", optimize.EventTypeInvoiceSettled, time.Now())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if status := deliver(h, body); status != http.StatusOK {
+			t.Errorf("status of first delivery = %d, want %d", status, http.StatusOK)
+		}
+	}()
+	<-started
+
+	if status := deliver(h, body); status != http.StatusConflict {
+		t.Errorf("status of concurrent delivery = %d, want %d", status, http.StatusConflict)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if status := deliver(h, body); status != http.StatusOK {
+		t.Errorf("status of later delivery = %d, want %d", status, http.StatusOK)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("callback called %d times, want 1", calls.Load())
+	}
+}
+
+func TestHandlerReleasesOnPanic(t *testing.T) {
+	h := newTestHandler(t)
+
+	panics := true
+	h.OnAny(func(context.Context, *Event) error {
+		if panics {
+			panics = false
+			panic("callback panicked")
+		}
+		return nil
+	})
+
+	body := signedBody(t, testSecret, "wh-1", optimize.EventTypeInvoiceSettled, time.Now())
+	func() {
+		defer func() {
+			_ = recover()
+		}()
+		deliver(h, body)
+	}()
+
+	if status := deliver(h, body); status != http.StatusOK {
+		t.Errorf("status after panic = %d, want %d", status, http.StatusOK)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 const (
@@ -56,6 +57,7 @@ type Builder interface {
 	WithContentTypeJSON() Builder
 
 	// WithBody sets the request body to the provided io.Reader.
+	// The body is buffered when the request is built, so it can be sent again on a retry.
 	WithBody(body io.Reader) Builder
 
 	// WithJSONBody sets the request body to the JSON encoding of v.
@@ -168,8 +170,13 @@ func (r *request) DELETE() (*http.Request, error) {
 }
 
 func (r *request) build() (*http.Request, error) {
+	body, err := replayable(r.body)
+	if err != nil {
+		return nil, err
+	}
+
 	fullURL := r.baseURL + r.endpoint
-	req, err := http.NewRequestWithContext(r.ctx, r.method, fullURL, r.body)
+	req, err := http.NewRequestWithContext(r.ctx, r.method, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -181,3 +188,19 @@ func (r *request) build() (*http.Request, error) {
 
 	return req, nil
 }
+
+// replayable returns a body for which http.NewRequest sets GetBody, so the request can be replayed.
+// Bodies of other types than *bytes.Buffer, *bytes.Reader and *strings.Reader are read into memory.
+func replayable(body io.Reader) (io.Reader, error) {
+	switch body.(type) {
+	case nil, *bytes.Buffer, *bytes.Reader, *strings.Reader:
+		return body, nil
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	return bytes.NewReader(data), nil
+}